@@ -0,0 +1,51 @@
+package rescode
+
+import (
+	"errors"
+	"sort"
+)
+
+// BatchResult combines the per-item errors of a bulk operation into a single
+// RC created by base. Data is set to a map from item index to error message
+// and the item errors are joined as the wrapped cause, in index order. Nil
+// entries are ignored; if no item failed, BatchResult returns nil.
+func BatchResult(base RcCreator, itemErrors map[int]error) *RC {
+	indexes := make([]int, 0, len(itemErrors))
+	for index, err := range itemErrors {
+		if err != nil {
+			indexes = append(indexes, index)
+		}
+	}
+	if len(indexes) == 0 {
+		return nil
+	}
+	sort.Ints(indexes)
+
+	data := make(map[int]string, len(indexes))
+	causes := make([]error, 0, len(indexes))
+	for _, index := range indexes {
+		data[index] = itemErrors[index].Error()
+		causes = append(causes, itemErrors[index])
+	}
+
+	rc := base(errors.Join(causes...))
+	rc.Data = data
+	return rc
+}
+
+// FilterRCs returns the RCs found in errs, via errors.As, for which pred
+// reports true, in their original order. Errors that do not wrap an RC are
+// skipped; a nil pred keeps every RC.
+func FilterRCs(errs []error, pred func(*RC) bool) []*RC {
+	var result []*RC
+	for _, err := range errs {
+		var rc *RC
+		if !errors.As(err, &rc) {
+			continue
+		}
+		if pred == nil || pred(rc) {
+			result = append(result, rc)
+		}
+	}
+	return result
+}
@@ -0,0 +1,81 @@
+package rescode
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+)
+
+func TestBatchResult_AllSuccess(t *testing.T) {
+	base := New(1301, 400, codes.InvalidArgument, "batch failed")
+
+	if rc := BatchResult(base, nil); rc != nil {
+		t.Errorf("Expected nil RC for no item errors, got %v", rc)
+	}
+	if rc := BatchResult(base, map[int]error{0: nil, 1: nil}); rc != nil {
+		t.Errorf("Expected nil RC for all-nil item errors, got %v", rc)
+	}
+}
+
+func TestBatchResult_PartialFailure(t *testing.T) {
+	base := New(1301, 400, codes.InvalidArgument, "batch failed")
+	errFirst := errors.New("invalid name")
+	errThird := errors.New("duplicate id")
+
+	rc := BatchResult(base, map[int]error{0: errFirst, 1: nil, 2: errThird})
+	if rc == nil {
+		t.Fatal("Expected RC for partial failure")
+	}
+	if rc.Code != 1301 {
+		t.Errorf("Expected Code 1301, got %d", rc.Code)
+	}
+
+	data, ok := rc.Data.(map[int]string)
+	if !ok {
+		t.Fatalf("Expected Data to be map[int]string, got %T", rc.Data)
+	}
+	if len(data) != 2 || data[0] != "invalid name" || data[2] != "duplicate id" {
+		t.Errorf("Unexpected Data: %v", data)
+	}
+
+	if !errors.Is(rc.OriginalError(), errFirst) || !errors.Is(rc.OriginalError(), errThird) {
+		t.Error("Expected wrapped error to join all item errors")
+	}
+	if rc.Error() != "batch failed: invalid name\nduplicate id" {
+		t.Errorf("Unexpected Error(): %q", rc.Error())
+	}
+}
+
+func TestFilterRCs(t *testing.T) {
+	notFound := New(20001, 404, codes.NotFound, "Policy not found")()
+	invalid := New(20002, 400, codes.InvalidArgument, "Invalid policy kind")()
+	internal := New(20003, 500, codes.Internal, "Internal error")()
+	errs := []error{
+		notFound,
+		errors.New("plain error"),
+		nil,
+		fmt.Errorf("item 3: %w", invalid),
+		internal,
+	}
+
+	clientErrors := FilterRCs(errs, func(rc *RC) bool {
+		return rc.HttpCode >= 400 && rc.HttpCode < 500
+	})
+	if len(clientErrors) != 2 || clientErrors[0] != notFound || clientErrors[1] != invalid {
+		t.Errorf("Expected the 404 and 400 RCs in order, got %v", clientErrors)
+	}
+
+	byCode := FilterRCs(errs, func(rc *RC) bool { return rc.Code == 20003 })
+	if len(byCode) != 1 || byCode[0] != internal {
+		t.Errorf("Expected only code 20003, got %v", byCode)
+	}
+
+	if all := FilterRCs(errs, nil); len(all) != 3 {
+		t.Errorf("Expected a nil predicate to keep all 3 RCs, got %d", len(all))
+	}
+	if none := FilterRCs(nil, nil); none != nil {
+		t.Errorf("Expected nil for no errors, got %v", none)
+	}
+}
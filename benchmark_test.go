@@ -167,6 +167,35 @@ func BenchmarkGenerated_PolicyNotFound(b *testing.B) {
 	}
 }
 
+// benchmarkSink keeps results alive so the compiler cannot elide them.
+var benchmarkSink *RC
+
+func BenchmarkGenerated_PolicyNotFound_Shared(b *testing.B) {
+	create := NewShared(20001, 404, codes.NotFound, "Policy not found")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		benchmarkSink = create()
+	}
+}
+
+func BenchmarkGenerated_PolicyNotFound_Pooled(b *testing.B) {
+	create := NewPooled(20001, 404, codes.NotFound, "Policy not found")
+	err := errors.New("wrapped error")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		create(err).Release()
+	}
+}
+
+func BenchmarkGenerated_PolicyNotFound_Unpooled(b *testing.B) {
+	create := New(20001, 404, codes.NotFound, "Policy not found")
+	err := errors.New("wrapped error")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		benchmarkSink = create(err)
+	}
+}
+
 func BenchmarkLegacy_PolicyNotFound(b *testing.B) {
 	registry := NewLegacyRegistry()
 
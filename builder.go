@@ -0,0 +1,99 @@
+package rescode
+
+import (
+	"errors"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+)
+
+// Builder constructs an RC fluently for errors that are computed at runtime
+// and therefore don't fit the generated-constant model.
+type Builder struct {
+	code     uint64
+	message  string
+	httpCode int
+	rpcCode  codes.Code
+	rpcSet   bool
+	data     any
+	err      error
+}
+
+// Build starts a new Builder.
+func Build() *Builder {
+	return &Builder{}
+}
+
+// Code sets the unique error code.
+func (b *Builder) Code(code uint64) *Builder {
+	b.code = code
+	return b
+}
+
+// HTTP sets the HTTP status code.
+func (b *Builder) HTTP(code int) *Builder {
+	b.httpCode = code
+	return b
+}
+
+// GRPC sets the gRPC status code.
+func (b *Builder) GRPC(code codes.Code) *Builder {
+	b.rpcCode = code
+	b.rpcSet = true
+	return b
+}
+
+// Message sets the human-readable error message.
+func (b *Builder) Message(message string) *Builder {
+	b.message = message
+	return b
+}
+
+// Data sets the optional additional data.
+func (b *Builder) Data(data any) *Builder {
+	b.data = data
+	return b
+}
+
+// Wrap sets the wrapped original error.
+func (b *Builder) Wrap(err error) *Builder {
+	b.err = err
+	return b
+}
+
+// Validate reports whether the required fields (code and message) are set.
+func (b *Builder) Validate() error {
+	if b.code == 0 {
+		return errors.New("rescode: builder code cannot be 0")
+	}
+	if b.message == "" {
+		return errors.New("rescode: builder message cannot be empty")
+	}
+	return nil
+}
+
+// RC returns the constructed RC, or the Validate error if the required fields
+// are missing. An unset HTTP code defaults to 500 and an unset gRPC code
+// defaults to codes.Unknown.
+func (b *Builder) RC() (*RC, error) {
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+
+	rc := &RC{
+		Code:     b.code,
+		Message:  b.message,
+		HttpCode: b.httpCode,
+		RpcCode:  b.rpcCode,
+		Data:     b.data,
+		err:      b.err,
+	}
+	if rc.HttpCode == 0 {
+		rc.HttpCode = http.StatusInternalServerError
+	}
+	if !b.rpcSet {
+		rc.RpcCode = codes.Unknown
+	}
+
+	return rc, nil
+}
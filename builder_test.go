@@ -0,0 +1,84 @@
+package rescode
+
+import (
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+)
+
+func TestBuilder_Full(t *testing.T) {
+	cause := errors.New("lookup failed")
+	rc, err := Build().
+		Code(20001).
+		HTTP(404).
+		GRPC(codes.NotFound).
+		Message("Policy not found").
+		Data("policy-42").
+		Wrap(cause).
+		RC()
+
+	if err != nil {
+		t.Fatalf("Expected RC to be built, got %v", err)
+	}
+	if rc.Code != 20001 {
+		t.Errorf("Expected Code 20001, got %d", rc.Code)
+	}
+	if rc.HttpCode != 404 {
+		t.Errorf("Expected HttpCode 404, got %d", rc.HttpCode)
+	}
+	if rc.RpcCode != codes.NotFound {
+		t.Errorf("Expected RpcCode NotFound, got %v", rc.RpcCode)
+	}
+	if rc.Message != "Policy not found" {
+		t.Errorf("Expected Message 'Policy not found', got %s", rc.Message)
+	}
+	if rc.Data != "policy-42" {
+		t.Errorf("Expected Data 'policy-42', got %v", rc.Data)
+	}
+	if rc.OriginalError() != cause {
+		t.Errorf("Expected OriginalError() %v, got %v", cause, rc.OriginalError())
+	}
+}
+
+func TestBuilder_Partial(t *testing.T) {
+	rc, err := Build().Code(20002).Message("Something went wrong").RC()
+
+	if err != nil {
+		t.Fatalf("Expected RC to be built, got %v", err)
+	}
+	if rc.HttpCode != 500 {
+		t.Errorf("Expected default HttpCode 500, got %d", rc.HttpCode)
+	}
+	if rc.RpcCode != codes.Unknown {
+		t.Errorf("Expected default RpcCode Unknown, got %v", rc.RpcCode)
+	}
+	if rc.Data != nil {
+		t.Errorf("Expected Data to be nil, got %v", rc.Data)
+	}
+}
+
+func TestBuilder_MissingRequired(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder *Builder
+	}{
+		{name: "missing code", builder: Build().Message("no code")},
+		{name: "missing message", builder: Build().Code(20003)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.builder.Validate(); err == nil {
+				t.Error("Expected Validate to return an error")
+			}
+			rc, err := tt.builder.RC()
+			if err == nil {
+				t.Error("Expected RC to return an error")
+			}
+			if rc != nil {
+				t.Errorf("Expected nil RC, got %v", rc)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package rescode
+
+import (
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// callerEnabled is set by EnableCaller.
+var callerEnabled atomic.Bool
+
+// EnableCaller turns recording of each new RC's call site on or off. While
+// enabled, creators returned by New record the file:line that called them,
+// reported by Caller, String and JSON. Frames inside this package and in
+// files named *_gen.go, where rescodegen writes factories by default, are
+// skipped so the location is the code that asked for the error. It is off by
+// default, leaving creation free of the runtime.Callers cost.
+func EnableCaller(enabled bool) {
+	callerEnabled.Store(enabled)
+}
+
+// packageDir is the source directory of this package, used to skip its
+// frames when capturing the caller.
+var packageDir = func() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Dir(file)
+}()
+
+// captureCaller returns the file:line of the first frame outside this package
+// and generated files, starting skip frames above captureCaller's caller.
+func captureCaller(skip int) string {
+	pcs := make([]uintptr, maxStackDepth)
+	n := runtime.Callers(skip+2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		internal := filepath.Dir(frame.File) == packageDir && !strings.HasSuffix(frame.File, "_test.go")
+		if !internal && !strings.HasSuffix(frame.File, "_gen.go") {
+			return frame.File + ":" + strconv.Itoa(frame.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+// Caller returns the file:line where the RC was created, or "" if
+// EnableCaller was off at the time.
+func (r *RC) Caller() string {
+	return r.caller
+}
@@ -0,0 +1,55 @@
+package rescode
+
+import (
+	"errors"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+)
+
+func TestEnableCaller(t *testing.T) {
+	EnableCaller(true)
+	t.Cleanup(func() { EnableCaller(false) })
+
+	create := New(20001, 404, codes.NotFound, "Policy not found")
+	_, file, line, _ := runtime.Caller(0)
+	rc := create(errors.New("no rows"))
+
+	want := file + ":" + strconv.Itoa(line+1)
+	if rc.Caller() != want {
+		t.Errorf("Expected caller %q, got %q", want, rc.Caller())
+	}
+	if rc.JSON()["caller"] != want {
+		t.Errorf("Expected caller in JSON, got %v", rc.JSON()["caller"])
+	}
+	if !strings.Contains(rc.String(), "Caller:"+want) {
+		t.Errorf("Expected caller in String(), got %q", rc.String())
+	}
+}
+
+func TestEnableCaller_SkipsWrappers(t *testing.T) {
+	EnableCaller(true)
+	t.Cleanup(func() { EnableCaller(false) })
+
+	// NewSync wraps New, so the location must skip its frames too
+	_, file, line, _ := runtime.Caller(0)
+	rc := NewSync(20001, 404, codes.NotFound, "Policy not found")()
+
+	if want := file + ":" + strconv.Itoa(line+1); rc.Caller() != want {
+		t.Errorf("Expected caller %q, got %q", want, rc.Caller())
+	}
+}
+
+func TestEnableCaller_Disabled(t *testing.T) {
+	rc := New(20001, 404, codes.NotFound, "Policy not found")()
+
+	if rc.Caller() != "" {
+		t.Errorf("Expected no caller by default, got %q", rc.Caller())
+	}
+	if _, ok := rc.JSON()["caller"]; ok {
+		t.Error("JSON should omit caller when not captured")
+	}
+}
@@ -0,0 +1,70 @@
+package rescode
+
+import "errors"
+
+// OriginalErrorChain returns the errors wrapped beneath r, outermost first.
+// RCs are followed through their wrapped error and other errors through
+// errors.Unwrap. Traversal stops before an RC that was already visited, so a
+// chain that wraps itself terminates instead of looping.
+func (r *RC) OriginalErrorChain() []error {
+	chain, _ := r.walkChain()
+	return chain
+}
+
+// Chain returns r followed by every error wrapped beneath it, in the order
+// OriginalErrorChain walks them, for logging the full cause chain.
+func (r *RC) Chain() []error {
+	chain, _ := r.walkChain()
+	return append([]error{r}, chain...)
+}
+
+// JSONChain returns the JSON representation of r followed by that of each RC
+// in its chain, without originalError since the chain itself records it. A
+// non-RC error ends the chain as a map holding only its originalError text.
+// Like OriginalErrorChain, it stops at the first repeated RC.
+func (r *RC) JSONChain(keys ...string) []map[string]interface{} {
+	names := currentJSONKeys()
+	result := []map[string]interface{}{r.Public().JSON(keys...)}
+
+	chain, _ := r.walkChain()
+	for _, err := range chain {
+		rc, ok := err.(*RC)
+		if !ok {
+			result = append(result, map[string]interface{}{names.OriginalError: err.Error()})
+			break
+		}
+		result = append(result, rc.Public().JSON(keys...))
+	}
+
+	return result
+}
+
+// HasCycle reports whether r's chain of wrapped errors leads back to an RC
+// already in the chain, such as an RC that wraps itself. Error, JSON,
+// errors.Is and errors.As do not guard against cycles, so callers handling
+// untrusted chains should check first.
+func (r *RC) HasCycle() bool {
+	_, cycle := r.walkChain()
+	return cycle
+}
+
+// walkChain collects the errors wrapped beneath r and reports whether the
+// walk stopped because an RC repeated.
+func (r *RC) walkChain() (chain []error, cycle bool) {
+	visited := map[*RC]bool{r: true}
+	_, err := r.guarded()
+	for err != nil {
+		if rc, ok := err.(*RC); ok {
+			if visited[rc] {
+				return chain, true
+			}
+			visited[rc] = true
+			chain = append(chain, err)
+			_, err = rc.guarded()
+			continue
+		}
+		chain = append(chain, err)
+		err = errors.Unwrap(err)
+	}
+	return chain, false
+}
@@ -0,0 +1,112 @@
+package rescode
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+)
+
+func TestRC_OriginalErrorChain(t *testing.T) {
+	root := errors.New("connection refused")
+	inner := New(1001, 503, codes.Unavailable, "Database unavailable")(fmt.Errorf("dial: %w", root))
+	outer := New(1002, 500, codes.Internal, "Failed to load policy")(inner)
+
+	chain := outer.OriginalErrorChain()
+	if len(chain) != 3 {
+		t.Fatalf("Expected 3 errors in chain, got %d", len(chain))
+	}
+	if chain[0] != error(inner) || chain[2] != root {
+		t.Errorf("Expected chain inner -> dial -> root, got %v", chain)
+	}
+	if outer.HasCycle() {
+		t.Error("Expected no cycle")
+	}
+
+	jsonChain := outer.JSONChain()
+	if len(jsonChain) != 3 {
+		t.Fatalf("Expected 3 JSON entries, got %d", len(jsonChain))
+	}
+	if jsonChain[1]["code"] != uint64(1001) {
+		t.Errorf("Expected second entry code 1001, got %v", jsonChain[1]["code"])
+	}
+	if _, ok := jsonChain[1]["originalError"]; ok {
+		t.Error("Expected RC entries to omit originalError")
+	}
+	if jsonChain[2]["originalError"] != "dial: connection refused" {
+		t.Errorf("Expected leaf originalError, got %v", jsonChain[2]["originalError"])
+	}
+}
+
+func TestRC_Chain(t *testing.T) {
+	root := errors.New("connection refused")
+	inner := New(1001, 503, codes.Unavailable, "Database unavailable")(root)
+	outer := New(1002, 500, codes.Internal, "Failed to load policy")(inner)
+
+	chain := outer.Chain()
+	expected := []error{outer, inner, root}
+	if len(chain) != len(expected) {
+		t.Fatalf("Expected %d errors in chain, got %d", len(expected), len(chain))
+	}
+	for i, want := range expected {
+		if chain[i] != want {
+			t.Errorf("Expected chain[%d] to be %v, got %v", i, want, chain[i])
+		}
+	}
+
+	if single := New(1003, 400, codes.InvalidArgument, "Bad request")().Chain(); len(single) != 1 {
+		t.Errorf("Expected an RC without cause to yield itself only, got %v", single)
+	}
+}
+
+func TestRC_Unwrap(t *testing.T) {
+	root := errors.New("connection refused")
+	inner := New(1001, 503, codes.Unavailable, "Database unavailable")(root)
+	outer := New(1002, 500, codes.Internal, "Failed to load policy")(fmt.Errorf("load: %w", inner))
+
+	if outer.Unwrap() == nil {
+		t.Fatal("Expected Unwrap to return the wrapped error")
+	}
+	if !errors.Is(outer, root) {
+		t.Error("Expected errors.Is to find the root cause through the chain")
+	}
+	if !errors.Is(outer, New(1001, 503, codes.Unavailable, "")()) {
+		t.Error("Expected errors.Is to match the wrapped RC by code")
+	}
+
+	var rc *RC
+	if !errors.As(outer.Unwrap(), &rc) || rc != inner {
+		t.Errorf("Expected errors.As to reach the wrapped RC, got %v", rc)
+	}
+
+	if New(1003, 400, codes.InvalidArgument, "Bad request")().Unwrap() != nil {
+		t.Error("Expected nil from Unwrap without a cause")
+	}
+}
+
+func TestRC_HasCycle(t *testing.T) {
+	self := New(1001, 500, codes.Internal, "Self")()
+	self.WrapWith(self)
+
+	if !self.HasCycle() {
+		t.Error("Expected a self-wrapping RC to have a cycle")
+	}
+	if chain := self.OriginalErrorChain(); len(chain) != 0 {
+		t.Errorf("Expected empty chain for self-wrapping RC, got %d entries", len(chain))
+	}
+
+	a := New(1001, 500, codes.Internal, "A")()
+	b := New(1002, 500, codes.Internal, "B")(a)
+	a.WrapWith(b)
+
+	if !a.HasCycle() {
+		t.Error("Expected a mutually wrapping chain to have a cycle")
+	}
+	if chain := a.OriginalErrorChain(); len(chain) != 1 || chain[0] != error(b) {
+		t.Errorf("Expected chain to stop after b, got %v", len(chain))
+	}
+	if jsonChain := a.JSONChain(); len(jsonChain) != 2 {
+		t.Errorf("Expected 2 JSON entries, got %d", len(jsonChain))
+	}
+}
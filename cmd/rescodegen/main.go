@@ -3,10 +3,16 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
 
 	"github.com/restayway/rescode/internal/generator"
 )
@@ -15,11 +21,51 @@ const version = "1.0.0"
 
 func main() {
 	var (
-		input   = flag.String("input", "", "Path to YAML/JSON file containing error definitions (required)")
-		output  = flag.String("output", "rescode_gen.go", "Path to generated Go file")
-		pkg     = flag.String("package", "", "Go package name to use in generated code (defaults to package of output file directory)")
-		showVer = flag.Bool("version", false, "Show version information")
-		help    = flag.Bool("help", false, "Show help information")
+		input    = flag.String("input", "", "Path to YAML/JSON/.proto file containing error definitions (required); comma-separate several to merge them in order")
+		output   = flag.String("output", "rescode_gen.go", "Path to generated Go file")
+		pkg      = flag.String("package", "", "Go package name to use in generated code (defaults to package of output file directory)")
+		subpkgs  = flag.Bool("emit-subpackages", false, "Write each category into its own subdirectory and package")
+		splitBy  = flag.String("split-by", "", "Split the generated package across files; \"group\" writes one file per group")
+		onDup    = flag.String("on-duplicate", generator.OnDuplicateError, "How a key defined by several inputs is merged: error, last-wins or first-wins")
+		codeRng  = flag.String("code-range", "", "Inclusive range every code must fall within, e.g. 20000-20999")
+		codePfx  = flag.String("code-prefix", "", "Leading digits every code must start with, e.g. 2 for 2xxxx")
+		codePat  = flag.String("code-pattern", "", "Regular expression every code, in decimal, must match in full")
+		openAPI  = flag.String("from-openapi", "", "Path to an OpenAPI spec to read error definitions from instead of --input")
+		sentinel = flag.Bool("gen-sentinels", false, "Emit an ErrXxx sentinel per error for use with errors.Is")
+		codeEnum = flag.Bool("gen-code-enum", false, "Emit a typed Code enumeration with AllCodes() and String()")
+		genMust  = flag.Bool("gen-must", false, "Emit MustXxx(err error) factories that panic when err is nil")
+		guard    = flag.Bool("gen-count-guard", false, "Emit ErrorCount and a compile-time check that it matches the factories")
+		genResp  = flag.Bool("gen-responses", false, "Emit XxxResponse() constructors returning a typed rescode.Response body")
+		genValid = flag.Bool("gen-validation", false, "Emit ValidationError(field) returning the error mapped to a failed request field")
+		keyLook  = flag.Bool("gen-key-for-code", false, "Emit KeyForCode(code) returning the key for a numeric code")
+		genCLI   = flag.Bool("gen-cli", false, "Emit ErrorsCommand(args, w) implementing an \"errors list\" subcommand")
+		genDec   = flag.Bool("gen-decoder", false, "Emit DecodeError(body) rebuilding an RC from a JSON error response")
+		genUUIDs = flag.Bool("gen-uuids", false, "Emit an XxxUUID constant per error, derived from the key when no uuid is given")
+		genKeys  = flag.Bool("gen-keys", false, "Emit a sorted Keys slice listing every error key")
+		genSSE   = flag.Bool("gen-sse", false, "Emit a CatalogSSE handler streaming the catalog as Server-Sent Events")
+		emitTest = flag.Bool("emit-test", false, "Also emit a _test.go file asserting each factory's code, HTTP status, gRPC code and message")
+		grpcTest = flag.Bool("gen-grpc-test", false, "Also emit a _grpc_test.go file asserting each factory's GRPCStatus()")
+		grpcRT   = flag.Bool("gen-grpc-roundtrip-test", false, "Also emit a _grpc_roundtrip_test.go file asserting FromGRPCStatus(GRPCStatus()) per factory")
+		allowOK  = flag.Bool("allow-ok-code", false, "Permit definitions with grpc 0 (OK), which GRPCStatus() reports as success")
+		fixMap   = flag.Bool("fix-mapping", false, "Correct gRPC codes that disagree with their HTTP status instead of warning")
+		watchIn  = flag.Bool("watch", false, "Regenerate whenever the input file changes")
+		rangeDoc = flag.String("emit-ranges-doc", "", "Also write a markdown table of code ranges per category to this file")
+		catDoc   = flag.String("emit-catalog-doc", "", "Also write a markdown table of every error, including meta fields, to this file")
+		dotPath  = flag.String("emit-dot", "", "Also write a Graphviz DOT graph of errors clustered by category to this file")
+		oaOut    = flag.String("openapi-output", "", "Also write OpenAPI response components for each HTTP status to this file")
+		schema   = flag.String("emit-schema", "", "Write a JSON Schema for the input file format to this file and exit")
+		typedCst = flag.Bool("typed-constants", false, "Emit the XxxHTTP constants as a named HTTPStatus type instead of int")
+		maxMsg   = flag.Int("max-message-length", 0, "Reject messages longer than this many characters (0 for no limit)")
+		codeType = flag.String("code-type", "uint64", "Unsigned integer type of the emitted code constants (uint8, uint16, uint32 or uint64)")
+		impPath  = flag.String("import-path", generator.DefaultImportPath, "Import path of the rescode package in generated code, for forks and vendored copies")
+		permStr  = flag.String("perm", "0644", "File mode of written files, in octal")
+		verify   = flag.Bool("verify", false, "Check that the output files are up to date instead of writing them")
+		hdrPath  = flag.String("header-file", "", "Path to a file whose contents (e.g. a license) are prepended to generated files")
+		tmplPath = flag.String("template", "", "Path to a Go text/template to render instead of the built-in layout")
+		quiet    = flag.Bool("quiet", false, "Suppress success messages; errors are still printed to stderr")
+		verbose  = flag.Bool("verbose", false, "Print parsing details such as the input format, definition count and package name")
+		showVer  = flag.Bool("version", false, "Show version information")
+		help     = flag.Bool("help", false, "Show help information")
 	)
 
 	flag.Parse()
@@ -34,57 +80,519 @@ func main() {
 		return
 	}
 
-	if *input == "" {
+	perm, err := parsePerm(*permStr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *schema != "" {
+		out, err := generator.GenerateSchema()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: Failed to generate schema: %v\n", err)
+			os.Exit(1)
+		}
+		if err := writeFileMode(*schema, out, perm); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Successfully generated %s\n", *schema)
+		return
+	}
+
+	if *quiet && *verbose {
+		fmt.Fprintf(os.Stderr, "Error: --quiet and --verbose cannot be used together\n")
+		os.Exit(1)
+	}
+
+	if *input == "" && *openAPI == "" {
 		fmt.Fprintf(os.Stderr, "Error: --input is required\n\n")
 		showHelp()
 		os.Exit(1)
 	}
 
-	// Open input file
-	inputFile, err := os.Open(*input)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to open input file %s: %v\n", *input, err)
+	opts := options{
+		input:      *input,
+		openAPI:    *openAPI,
+		output:     *output,
+		pkg:        *pkg,
+		subpkgs:    *subpkgs,
+		splitBy:    *splitBy,
+		onDup:      *onDup,
+		codeRange:  *codeRng,
+		codePrefix: *codePfx,
+		codePat:    *codePat,
+		maxMessage: *maxMsg,
+		rangesDoc:  *rangeDoc,
+		catalogDoc: *catDoc,
+		dot:        *dotPath,
+		openAPIOut: *oaOut,
+		emitTest:   *emitTest,
+		grpcTest:   *grpcTest,
+		grpcRound:  *grpcRT,
+		sentinels:  *sentinel,
+		codeEnum:   *codeEnum,
+		must:       *genMust,
+		fixMapping: *fixMap,
+		allowOK:    *allowOK,
+		countGuard: *guard,
+		sse:        *genSSE,
+		keys:       *genKeys,
+		responses:  *genResp,
+		validation: *genValid,
+		keyForCode: *keyLook,
+		cli:        *genCLI,
+		decoder:    *genDec,
+		typedConst: *typedCst,
+		uuids:      *genUUIDs,
+		template:   *tmplPath,
+		headerFile: *hdrPath,
+		perm:       perm,
+		verify:     *verify,
+		codeType:   *codeType,
+		importPath: *impPath,
+		quiet:      *quiet,
+		verbose:    *verbose,
+	}
+
+	if *watchIn {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		fmt.Printf("Watching %s for changes (Ctrl-C to stop)\n", strings.Join(opts.inputPaths(), ", "))
+		watch(ctx, opts.inputPaths(), pollInterval, func() error { return generate(opts) }, os.Stdout, os.Stderr)
+		return
+	}
+
+	if err := generate(opts); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
+}
+
+// options holds the parsed command-line flags.
+type options struct {
+	input      string
+	openAPI    string
+	output     string
+	pkg        string
+	subpkgs    bool
+	splitBy    string
+	onDup      string
+	codeRange  string
+	codePrefix string
+	codePat    string
+	maxMessage int
+	rangesDoc  string
+	catalogDoc string
+	dot        string
+	openAPIOut string
+	emitTest   bool
+	grpcTest   bool
+	grpcRound  bool
+	sentinels  bool
+	codeEnum   bool
+	must       bool
+	fixMapping bool
+	allowOK    bool
+	countGuard bool
+	sse        bool
+	keys       bool
+	responses  bool
+	validation bool
+	keyForCode bool
+	cli        bool
+	decoder    bool
+	typedConst bool
+	uuids      bool
+	template   string
+	headerFile string
+	perm       os.FileMode
+	verify     bool
+	codeType   string
+	importPath string
+	quiet      bool
+	verbose    bool
+}
+
+// inputPaths returns the definitions files to read, in merge order.
+func (o options) inputPaths() []string {
+	if o.openAPI != "" {
+		return []string{o.openAPI}
+	}
+	return strings.Split(o.input, ",")
+}
+
+// parseInput opens and parses the definitions file at path.
+func (o options) parseInput(path string) ([]generator.ErrorDefinition, error) {
+	inputFile, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to open input file %s: %v", path, err)
+	}
 	defer inputFile.Close()
 
-	// Parse error definitions
-	errors, err := generator.ParseInput(inputFile, *input)
+	var errors []generator.ErrorDefinition
+	if o.openAPI != "" {
+		errors, err = generator.ParseOpenAPI(inputFile)
+	} else {
+		errors, err = generator.ParseInput(inputFile, path)
+	}
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to parse input file: %v\n", err)
-		os.Exit(1)
+		return nil, fmt.Errorf("Failed to parse input file %s: %v", path, err)
+	}
+	return errors, nil
+}
+
+// generate parses the input and writes every requested output file.
+func generate(opts options) error {
+	inputPaths := opts.inputPaths()
+
+	if opts.splitBy != "" && opts.splitBy != "group" {
+		return fmt.Errorf("unsupported --split-by %q: only \"group\" is supported", opts.splitBy)
+	}
+	if opts.splitBy != "" && opts.subpkgs {
+		return fmt.Errorf("--split-by cannot be combined with --emit-subpackages")
+	}
+
+	// Read and compile the custom template before doing any work
+	var tmpl string
+	if opts.template != "" {
+		data, err := os.ReadFile(opts.template)
+		if err != nil {
+			return fmt.Errorf("Failed to read template file %s: %v", opts.template, err)
+		}
+		if _, err := generator.ParseTemplate(string(data)); err != nil {
+			return err
+		}
+		tmpl = string(data)
+	}
+
+	var header string
+	if opts.headerFile != "" {
+		data, err := os.ReadFile(opts.headerFile)
+		if err != nil {
+			return fmt.Errorf("Failed to read header file %s: %v", opts.headerFile, err)
+		}
+		header = string(data)
+	}
+
+	// Parse error definitions from each input and merge them in order
+	inputs := make([][]generator.ErrorDefinition, 0, len(inputPaths))
+	for _, path := range inputPaths {
+		defs, err := opts.parseInput(path)
+		if err != nil {
+			return err
+		}
+		opts.logf("Parsed %d error definitions from %s (format: %s)\n", len(defs), path, opts.inputFormat(path))
+		inputs = append(inputs, defs)
+	}
+	errors, err := generator.MergeDefinitions(inputs, opts.onDup)
+	if err != nil {
+		return fmt.Errorf("Failed to merge input files %s: %v", strings.Join(inputPaths, ", "), err)
+	}
+
+	for _, message := range generator.ReconcileMapping(errors, opts.fixMapping) {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", message)
+	}
+
+	// An OK code makes GRPCStatus() report success, so it is opt-in
+	if !opts.allowOK {
+		if err := generator.ValidateNoOKCodes(errors); err != nil {
+			return fmt.Errorf("%v (use --allow-ok-code to permit them)", err)
+		}
+	}
+
+	if opts.codeRange != "" {
+		min, max, err := generator.ParseCodeRange(opts.codeRange)
+		if err != nil {
+			return err
+		}
+		if err := generator.ValidateCodeRange(errors, min, max); err != nil {
+			return err
+		}
+	}
+
+	if opts.codePrefix != "" {
+		if err := generator.ValidateCodePrefix(errors, opts.codePrefix); err != nil {
+			return err
+		}
+	}
+
+	if opts.codePat != "" {
+		if err := generator.ValidateCodePattern(errors, opts.codePat); err != nil {
+			return err
+		}
+	}
+
+	if opts.maxMessage > 0 {
+		if err := generator.ValidateMessageLength(errors, opts.maxMessage); err != nil {
+			return err
+		}
 	}
 
 	// Determine package name
-	packageName := *pkg
+	packageName := opts.pkg
 	if packageName == "" {
 		// Default to the directory name of the output file
-		dir := filepath.Dir(*output)
+		dir := filepath.Dir(opts.output)
 		if dir == "." {
 			dir, _ = os.Getwd()
 		}
 		packageName = filepath.Base(dir)
 	}
+	opts.logf("Package: %s\n", packageName)
+
+	if opts.rangesDoc != "" {
+		if err := opts.writeFile(opts.rangesDoc, generator.GenerateRangesDoc(errors)); err != nil {
+			return err
+		}
+	}
+
+	if opts.catalogDoc != "" {
+		if err := opts.writeFile(opts.catalogDoc, generator.GenerateCatalogDoc(errors)); err != nil {
+			return err
+		}
+	}
 
-	// Generate code
+	if opts.dot != "" {
+		if err := opts.writeFile(opts.dot, generator.GenerateDOT(errors)); err != nil {
+			return err
+		}
+	}
+
+	if opts.openAPIOut != "" {
+		spec, err := generator.GenerateOpenAPI(errors)
+		if err != nil {
+			return err
+		}
+		if err := opts.writeFile(opts.openAPIOut, spec); err != nil {
+			return err
+		}
+	}
+
+	// Generation options shared by every emitted file
 	config := generator.Config{
-		Package: packageName,
-		Errors:  errors,
+		Package:        packageName,
+		Errors:         errors,
+		Sentinels:      opts.sentinels,
+		CodeEnum:       opts.codeEnum,
+		Must:           opts.must,
+		CountGuard:     opts.countGuard,
+		SSE:            opts.sse,
+		Keys:           opts.keys,
+		Responses:      opts.responses,
+		Validation:     opts.validation,
+		KeyForCode:     opts.keyForCode,
+		CLI:            opts.cli,
+		Decoder:        opts.decoder,
+		TypedConstants: opts.typedConst,
+		UUIDs:          opts.uuids,
+		CodeType:       opts.codeType,
+		ImportPath:     opts.importPath,
+		Template:       tmpl,
+		Header:         header,
+	}
+
+	if opts.subpkgs {
+		uncategorized, categories, grouped := generator.GroupByCategory(errors)
+		outDir := filepath.Dir(opts.output)
+		outName := filepath.Base(opts.output)
+
+		for _, category := range categories {
+			if !opts.verify {
+				if err := os.MkdirAll(filepath.Join(outDir, category), 0755); err != nil {
+					return fmt.Errorf("Failed to create subpackage directory %s: %v", category, err)
+				}
+			}
+			path := filepath.Join(outDir, category, outName)
+			subConfig := config
+			subConfig.Package, subConfig.Errors = category, grouped[category]
+			if err := opts.writeGenerated(path, subConfig); err != nil {
+				return err
+			}
+			opts.report(path, len(grouped[category]))
+			if err := opts.writeTests(path, subConfig); err != nil {
+				return err
+			}
+		}
+
+		if len(uncategorized) > 0 {
+			config.Errors = uncategorized
+			if err := opts.writeGenerated(opts.output, config); err != nil {
+				return err
+			}
+			opts.report(opts.output, len(uncategorized))
+			return opts.writeTests(opts.output, config)
+		}
+		return nil
+	}
+
+	if opts.splitBy != "" {
+		if err := opts.writeSplit(config); err != nil {
+			return err
+		}
+	} else {
+		if err := opts.writeGenerated(opts.output, config); err != nil {
+			return err
+		}
+		opts.report(opts.output, len(errors))
+	}
+
+	return opts.writeTests(opts.output, config)
+}
+
+// writeTests writes the test files requested by --emit-test, --gen-grpc-test
+// and --gen-grpc-roundtrip-test next to the generated file output.
+func (o options) writeTests(output string, config generator.Config) error {
+	if o.emitTest {
+		testPath := strings.TrimSuffix(output, ".go") + "_test.go"
+		code, err := generator.GenerateFactoryTest(config)
+		if err != nil {
+			return fmt.Errorf("Failed to generate factory test: %v", err)
+		}
+		if err := o.writeFile(testPath, code); err != nil {
+			return err
+		}
+	}
+
+	if o.grpcTest {
+		testPath := strings.TrimSuffix(output, ".go") + "_grpc_test.go"
+		code, err := generator.GenerateGRPCTest(config)
+		if err != nil {
+			return fmt.Errorf("Failed to generate gRPC test: %v", err)
+		}
+		if err := o.writeFile(testPath, code); err != nil {
+			return err
+		}
+	}
+
+	if o.grpcRound {
+		testPath := strings.TrimSuffix(output, ".go") + "_grpc_roundtrip_test.go"
+		code, err := generator.GenerateGRPCRoundTripTest(config)
+		if err != nil {
+			return fmt.Errorf("Failed to generate gRPC round-trip test: %v", err)
+		}
+		if err := o.writeFile(testPath, code); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeSplit generates config as one file per group next to the output file,
+// named <base>_<group>_gen.go, with ungrouped errors and package-wide
+// declarations in the output file itself.
+func (o options) writeSplit(config generator.Config) error {
+	files, err := generator.GenerateSplit(config)
+	if err != nil {
+		return fmt.Errorf("Failed to generate code: %v", err)
+	}
+
+	counts := make(map[string]int)
+	for _, errDef := range config.Errors {
+		counts[errDef.Group]++
+	}
+
+	base := strings.TrimSuffix(strings.TrimSuffix(filepath.Base(o.output), ".go"), "_gen")
+	groups := make([]string, 0, len(files))
+	for group := range files {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+
+	for _, group := range groups {
+		path := o.output
+		if group != "" {
+			path = filepath.Join(filepath.Dir(o.output), base+"_"+strings.ToLower(group)+"_gen.go")
+		}
+		if err := o.writeFile(path, files[group]); err != nil {
+			return err
+		}
+		o.report(path, counts[group])
 	}
+	return nil
+}
 
+// writeGenerated generates code for config and writes it to path.
+func (o options) writeGenerated(path string, config generator.Config) error {
 	code, err := generator.Generate(config)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to generate code: %v\n", err)
-		os.Exit(1)
+		return fmt.Errorf("Failed to generate code: %v", err)
 	}
 
-	// Write output file
-	if err := os.WriteFile(*output, code, 0644); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to write output file %s: %v\n", *output, err)
-		os.Exit(1)
+	return o.writeFile(path, code)
+}
+
+// writeFile writes data to path, or with --verify checks that path already
+// holds exactly data.
+func (o options) writeFile(path string, data []byte) error {
+	if o.verify {
+		o.logf("Verifying %s\n", path)
+		return verifyFile(path, data)
 	}
 
-	fmt.Printf("Successfully generated %s with %d error definitions\n", *output, len(errors))
+	o.logf("Writing %s\n", path)
+
+	return writeFileMode(path, data, o.perm)
+}
+
+// writeFileMode writes data to path and sets its mode to perm, which unlike
+// os.WriteFile also applies to existing files and is not masked by the umask.
+func writeFileMode(path string, data []byte, perm os.FileMode) error {
+	if err := os.WriteFile(path, data, perm); err != nil {
+		return fmt.Errorf("Failed to write output file %s: %v", path, err)
+	}
+	if err := os.Chmod(path, perm); err != nil {
+		return fmt.Errorf("Failed to set mode of output file %s: %v", path, err)
+	}
+	return nil
+}
+
+// parsePerm parses an octal file mode such as "0644" for --perm.
+func parsePerm(s string) (os.FileMode, error) {
+	mode, err := strconv.ParseUint(s, 8, 32)
+	if err != nil || mode > 0777 {
+		return 0, fmt.Errorf("invalid --perm %q: expected an octal file mode such as 0644", s)
+	}
+	return os.FileMode(mode), nil
+}
+
+// inputFormat describes the format the input at path is parsed as, for
+// --verbose.
+func (o options) inputFormat(path string) string {
+	if o.openAPI != "" {
+		return "OpenAPI"
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json", ".jsonc", ".json5":
+		return "JSON"
+	case ".yaml", ".yml":
+		return "YAML"
+	case ".proto":
+		return "proto"
+	default:
+		return "auto-detected JSON or YAML"
+	}
+}
+
+// logf prints a progress detail when --verbose is set.
+func (o options) logf(format string, args ...any) {
+	if o.verbose {
+		fmt.Printf(format, args...)
+	}
+}
+
+// report prints the outcome for a generated file unless --quiet is set.
+func (o options) report(path string, count int) {
+	if o.quiet {
+		return
+	}
+	if o.verify {
+		fmt.Printf("%s is up to date\n", path)
+		return
+	}
+	fmt.Printf("Successfully generated %s with %d error definitions\n", path, count)
 }
 
 func showHelp() {
@@ -94,9 +602,75 @@ Usage:
   rescodegen --input <file> [--output <file>] [--package <name>]
 
 Options:
-  --input     Path to YAML/JSON file containing error definitions (required)
+  --input     Path to YAML/JSON/.proto file containing error definitions (required);
+              comma-separate several files to merge them in order
+  --on-duplicate
+              How a key defined by several inputs is merged: error (default), last-wins or first-wins
   --output    Path to generated Go file (default: rescode_gen.go)
   --package   Go package name to use in generated code (default: directory name)
+  --emit-subpackages
+              Write each category into <output dir>/<category>/ as package <category>
+  --split-by  Split the package across files; "group" writes <base>_<group>_gen.go per group
+  --code-range
+              Inclusive range every code must fall within (e.g. 20000-20999)
+  --code-prefix
+              Leading digits every code must start with (e.g. 2 for 2xxxx)
+  --code-pattern
+              Regular expression every code must match in full (e.g. 2\d{4})
+  --max-message-length
+              Reject definitions whose message is longer than this many characters
+  --code-type Type of the emitted code constants: uint8, uint16, uint32 or uint64 (default: uint64)
+  --import-path
+              Import path of the rescode package in generated code (default: github.com/restayway/rescode)
+  --typed-constants
+              Emit the XxxHTTP constants as a named HTTPStatus type instead of int
+  --from-openapi
+              Read error definitions from an OpenAPI spec instead of --input
+  --gen-sentinels
+              Emit an ErrXxx sentinel per error for use with errors.Is
+  --gen-code-enum
+              Emit a typed Code enumeration with AllCodes() and String()
+  --gen-must  Emit MustXxx(err error) factories that panic when err is nil
+  --gen-count-guard
+              Emit ErrorCount and a compile-time check that it matches the factories
+  --gen-keys  Emit a sorted Keys slice listing every error key
+  --gen-uuids Emit an XxxUUID constant per error, a UUIDv5 of the key unless uuid is set
+  --gen-cli   Emit ErrorsCommand(args, w) implementing an "errors list" subcommand
+  --gen-decoder
+              Emit DecodeError(body) rebuilding an RC from a JSON error response
+  --gen-key-for-code
+              Emit KeyForCode(code) returning the key for a numeric code, or "UNKNOWN(code)"
+  --gen-responses
+              Emit XxxResponse() constructors returning a typed rescode.Response body
+  --gen-validation
+              Emit ValidationError(field) returning the error whose fields list it
+  --gen-sse   Emit a CatalogSSE handler streaming the catalog as Server-Sent Events
+  --emit-test Also emit <output>_test.go asserting each factory's code, HTTP, gRPC and message
+  --gen-grpc-test
+              Also emit <output>_grpc_test.go asserting each factory's GRPCStatus()
+  --gen-grpc-roundtrip-test
+              Also emit <output>_grpc_roundtrip_test.go asserting FromGRPCStatus(GRPCStatus()) per factory
+  --emit-ranges-doc
+              Also write a markdown table of code ranges per category to this file
+  --emit-catalog-doc
+              Also write a markdown table of every error, including meta fields, to this file
+  --emit-dot  Also write a Graphviz graph clustering errors by category and colored by severity
+  --openapi-output
+              Also write OpenAPI response components and code enums per HTTP status to this file
+  --fix-mapping
+              Correct gRPC codes that disagree with their HTTP status instead of warning
+  --allow-ok-code
+              Permit grpc 0 (OK), rejected by default since GRPCStatus() would report success
+  --perm      File mode of written files, in octal (default: 0644)
+  --verify    Exit non-zero if the output files are stale instead of writing them
+  --watch     Regenerate whenever the input file changes (Ctrl-C to stop)
+  --emit-schema
+              Write a JSON Schema for the input file format to this file and exit
+  --header-file
+              Prepend this file's contents (e.g. a license) as comments to generated files
+  --template  Render this Go text/template (given the Config) instead of the built-in layout
+  --quiet     Suppress success messages (errors still go to stderr)
+  --verbose   Print parsing details: input format, definition count, package and output paths
   --version   Show version information
   --help      Show this help message
 
@@ -114,6 +688,8 @@ Input file format (YAML):
     http: 404
     grpc: 5
     desc: Policy could not be located in the database
+    category: policy
+    deprecated: use PolicyMissing instead   # or: deprecated: true
 
 Input file format (JSON):
   [
@@ -123,7 +699,8 @@ Input file format (JSON):
       "message": "Policy not found",
       "http": 404,
       "grpc": 5,
-      "desc": "Policy could not be located in the database"
+      "desc": "Policy could not be located in the database",
+      "category": "policy"
     }
   ]
 `)
@@ -1,11 +1,15 @@
 package main
 
 import (
+	"context"
+	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestCLI_Help(t *testing.T) {
@@ -162,3 +166,659 @@ func TestCLI_InvalidYAML(t *testing.T) {
 		t.Error("Error output should mention parsing failure")
 	}
 }
+
+func TestCLI_EmitSubpackages(t *testing.T) {
+	tmpDir := t.TempDir()
+	inputFile := filepath.Join(tmpDir, "errors.yaml")
+	outputFile := filepath.Join(tmpDir, "errors_gen.go")
+
+	yamlContent := `- code: 10001
+  key: LoginFailed
+  message: Login failed
+  http: 401
+  grpc: 16
+  category: auth
+- code: 20001
+  key: PolicyNotFound
+  message: Policy not found
+  http: 404
+  grpc: 5
+  category: policy`
+
+	if err := os.WriteFile(inputFile, []byte(yamlContent), 0644); err != nil {
+		t.Fatalf("Failed to create test input file: %v", err)
+	}
+
+	cmd := exec.Command("go", "run", ".", "--input", inputFile, "--output", outputFile, "--package", "errs", "--emit-subpackages")
+	cmd.Dir = filepath.Join("..", "..", "cmd", "rescodegen")
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, string(output))
+	}
+
+	expected := map[string]string{
+		"auth":   "func LoginFailed(err ...error)",
+		"policy": "func PolicyNotFound(err ...error)",
+	}
+	for category, function := range expected {
+		content, err := os.ReadFile(filepath.Join(tmpDir, category, "errors_gen.go"))
+		if err != nil {
+			t.Fatalf("Failed to read %s subpackage file: %v", category, err)
+		}
+
+		contentStr := string(content)
+		if !strings.Contains(contentStr, "package "+category+"\n") {
+			t.Errorf("Subpackage file for %s should declare package %s", category, category)
+		}
+		if !strings.Contains(contentStr, function) {
+			t.Errorf("Subpackage file for %s should contain %q", category, function)
+		}
+	}
+
+	// All definitions are categorized, so no top-level file is written
+	if _, err := os.Stat(outputFile); !os.IsNotExist(err) {
+		t.Error("Top-level output file should not be created when every definition has a category")
+	}
+}
+
+func TestCLI_EmitSubpackagesWithTests(t *testing.T) {
+	tmpDir := t.TempDir()
+	inputFile := filepath.Join(tmpDir, "errors.yaml")
+	outputFile := filepath.Join(tmpDir, "errors_gen.go")
+
+	yamlContent := `- code: 10001
+  key: LoginFailed
+  message: Login failed
+  http: 401
+  grpc: 16
+  category: auth
+- code: 90001
+  key: Internal
+  message: Internal error
+  http: 500
+  grpc: 13`
+
+	if err := os.WriteFile(inputFile, []byte(yamlContent), 0644); err != nil {
+		t.Fatalf("Failed to create test input file: %v", err)
+	}
+
+	cmd := exec.Command("go", "run", ".", "--input", inputFile, "--output", outputFile, "--package", "errs",
+		"--emit-subpackages", "--emit-test", "--gen-grpc-test", "--gen-grpc-roundtrip-test")
+	cmd.Dir = filepath.Join("..", "..", "cmd", "rescodegen")
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, string(output))
+	}
+
+	expected := map[string]string{
+		filepath.Join("auth", "errors_gen_test.go"):                "package auth\n",
+		filepath.Join("auth", "errors_gen_grpc_test.go"):           "{\"LoginFailed\", LoginFailed",
+		filepath.Join("auth", "errors_gen_grpc_roundtrip_test.go"): "{\"LoginFailed\", LoginFailed}",
+		"errors_gen_test.go":                                       "package errs\n",
+		"errors_gen_grpc_test.go":                                  "{\"Internal\", Internal",
+		"errors_gen_grpc_roundtrip_test.go":                        "{\"Internal\", Internal}",
+	}
+	for name, want := range expected {
+		content, err := os.ReadFile(filepath.Join(tmpDir, name))
+		if err != nil {
+			t.Errorf("Expected %s to be written: %v", name, err)
+			continue
+		}
+		if !strings.Contains(string(content), want) {
+			t.Errorf("%s should contain %q", name, want)
+		}
+	}
+	if content, err := os.ReadFile(filepath.Join(tmpDir, "errors_gen_test.go")); err == nil && strings.Contains(string(content), "LoginFailed") {
+		t.Error("Top-level test should not cover categorized definitions")
+	}
+}
+
+func TestCLI_ImportPath(t *testing.T) {
+	tmpDir := t.TempDir()
+	inputFile := filepath.Join(tmpDir, "errors.yaml")
+	outputFile := filepath.Join(tmpDir, "errors_gen.go")
+
+	yamlContent := `- code: 20001
+  key: PolicyNotFound
+  message: Policy not found
+  http: 404
+  grpc: 5`
+	if err := os.WriteFile(inputFile, []byte(yamlContent), 0644); err != nil {
+		t.Fatalf("Failed to create test input file: %v", err)
+	}
+
+	cmd := exec.Command("go", "run", ".", "--input", inputFile, "--output", outputFile, "--package", "errs",
+		"--import-path", "example.com/vendor/rescode", "--emit-test")
+	cmd.Dir = filepath.Join("..", "..", "cmd", "rescodegen")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, string(output))
+	}
+
+	for _, name := range []string{"errors_gen.go", "errors_gen_test.go"} {
+		content, err := os.ReadFile(filepath.Join(tmpDir, name))
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", name, err)
+		}
+		if !strings.Contains(string(content), `"example.com/vendor/rescode"`) {
+			t.Errorf("%s should import example.com/vendor/rescode", name)
+		}
+		if strings.Contains(string(content), "github.com/restayway/rescode") {
+			t.Errorf("%s should not import the canonical path", name)
+		}
+	}
+}
+
+func TestCLI_CodeRange(t *testing.T) {
+	tmpDir := t.TempDir()
+	inputFile := filepath.Join(tmpDir, "errors.yaml")
+	outputFile := filepath.Join(tmpDir, "errors_gen.go")
+
+	yamlContent := `- code: 20001
+  key: PolicyNotFound
+  message: Policy not found
+  http: 404
+  grpc: 5
+- code: 10001
+  key: LoginFailed
+  message: Login failed
+  http: 401
+  grpc: 16`
+
+	if err := os.WriteFile(inputFile, []byte(yamlContent), 0644); err != nil {
+		t.Fatalf("Failed to create test input file: %v", err)
+	}
+
+	cmd := exec.Command("go", "run", ".", "--input", inputFile, "--output", outputFile, "--code-range", "20000-20999")
+	cmd.Dir = filepath.Join("..", "..", "cmd", "rescodegen")
+
+	output, err := cmd.CombinedOutput()
+	if err == nil {
+		t.Error("Expected CLI to fail with out-of-range code")
+	}
+	if !strings.Contains(string(output), "LoginFailed (10001)") {
+		t.Errorf("Error output should list the out-of-range entry, got %s", string(output))
+	}
+
+	cmd = exec.Command("go", "run", ".", "--input", inputFile, "--output", outputFile, "--package", "errs", "--code-range", "10000-29999")
+	cmd.Dir = filepath.Join("..", "..", "cmd", "rescodegen")
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("CLI failed for in-range codes: %v\nOutput: %s", err, string(output))
+	}
+}
+
+func TestCLI_CodePrefix(t *testing.T) {
+	tmpDir := t.TempDir()
+	inputFile := filepath.Join(tmpDir, "errors.yaml")
+	outputFile := filepath.Join(tmpDir, "errors_gen.go")
+
+	yamlContent := `- code: 20001
+  key: PolicyNotFound
+  message: Policy not found
+  http: 404
+  grpc: 5
+- code: 10001
+  key: LoginFailed
+  message: Login failed
+  http: 401
+  grpc: 16`
+
+	if err := os.WriteFile(inputFile, []byte(yamlContent), 0644); err != nil {
+		t.Fatalf("Failed to create test input file: %v", err)
+	}
+
+	cmd := exec.Command("go", "run", ".", "--input", inputFile, "--output", outputFile, "--code-prefix", "2")
+	cmd.Dir = filepath.Join("..", "..", "cmd", "rescodegen")
+
+	output, err := cmd.CombinedOutput()
+	if err == nil {
+		t.Error("Expected CLI to fail with a code not matching the prefix")
+	}
+	if !strings.Contains(string(output), "LoginFailed (10001)") {
+		t.Errorf("Error output should list the offending entry, got %s", string(output))
+	}
+
+	cmd = exec.Command("go", "run", ".", "--input", inputFile, "--output", outputFile, "--package", "errs", "--code-pattern", `[12]\d{4}`)
+	cmd.Dir = filepath.Join("..", "..", "cmd", "rescodegen")
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("CLI failed for matching codes: %v\nOutput: %s", err, string(output))
+	}
+}
+
+func TestCLI_OnDuplicate(t *testing.T) {
+	tmpDir := t.TempDir()
+	baseFile := filepath.Join(tmpDir, "base.yaml")
+	overrideFile := filepath.Join(tmpDir, "override.yaml")
+	outputFile := filepath.Join(tmpDir, "errors_gen.go")
+
+	base := `- code: 20001
+  key: PolicyNotFound
+  message: Policy not found
+  http: 404
+- code: 20002
+  key: InvalidKind
+  message: Invalid policy kind
+  http: 400`
+	override := `- code: 20001
+  key: PolicyNotFound
+  message: No such policy
+  http: 404`
+
+	if err := os.WriteFile(baseFile, []byte(base), 0644); err != nil {
+		t.Fatalf("Failed to create base input file: %v", err)
+	}
+	if err := os.WriteFile(overrideFile, []byte(override), 0644); err != nil {
+		t.Fatalf("Failed to create override input file: %v", err)
+	}
+
+	run := func(mode string) (string, error) {
+		args := []string{"run", ".", "--input", baseFile + "," + overrideFile, "--output", outputFile, "--package", "errs"}
+		if mode != "" {
+			args = append(args, "--on-duplicate", mode)
+		}
+		cmd := exec.Command("go", args...)
+		cmd.Dir = filepath.Join("..", "..", "cmd", "rescodegen")
+		output, err := cmd.CombinedOutput()
+		return string(output), err
+	}
+
+	output, err := run("")
+	if err == nil {
+		t.Error("Expected CLI to fail on a key defined by two inputs")
+	}
+	if !strings.Contains(output, "key PolicyNotFound of input 1 is already defined by input 0") {
+		t.Errorf("Error output should name the duplicate key, got %s", output)
+	}
+
+	for mode, message := range map[string]string{"last-wins": "No such policy", "first-wins": "Policy not found"} {
+		if output, err := run(mode); err != nil {
+			t.Fatalf("CLI failed with --on-duplicate %s: %v\nOutput: %s", mode, err, output)
+		}
+		content, err := os.ReadFile(outputFile)
+		if err != nil {
+			t.Fatalf("Failed to read output file: %v", err)
+		}
+		if !strings.Contains(string(content), `"`+message+`"`) {
+			t.Errorf("Expected %s to keep message %q, got:\n%s", mode, message, content)
+		}
+		if !strings.Contains(string(content), "func InvalidKind(") {
+			t.Errorf("Expected %s to keep InvalidKind from the base file", mode)
+		}
+	}
+
+	if output, err := run("newest"); err == nil || !strings.Contains(output, "unsupported duplicate mode") {
+		t.Errorf("Expected an unsupported mode error, got %v: %s", err, output)
+	}
+}
+
+func TestCLI_AllowOKCode(t *testing.T) {
+	tmpDir := t.TempDir()
+	inputFile := filepath.Join(tmpDir, "errors.yaml")
+	outputFile := filepath.Join(tmpDir, "errors_gen.go")
+
+	yamlContent := `- code: 20001
+  key: PolicyNotFound
+  message: Policy not found
+  http: 404
+- code: 20002
+  key: Accepted
+  message: Request accepted
+  http: 202
+  grpc: 0`
+
+	if err := os.WriteFile(inputFile, []byte(yamlContent), 0644); err != nil {
+		t.Fatalf("Failed to create test input file: %v", err)
+	}
+
+	cmd := exec.Command("go", "run", ".", "--input", inputFile, "--output", outputFile, "--package", "errs")
+	cmd.Dir = filepath.Join("..", "..", "cmd", "rescodegen")
+
+	output, err := cmd.CombinedOutput()
+	if err == nil {
+		t.Error("Expected CLI to reject grpc 0 by default")
+	}
+	if !strings.Contains(string(output), "Accepted (20002)") || !strings.Contains(string(output), "--allow-ok-code") {
+		t.Errorf("Error output should list the OK entry and the flag, got %s", string(output))
+	}
+
+	cmd = exec.Command("go", "run", ".", "--input", inputFile, "--output", outputFile, "--package", "errs", "--allow-ok-code")
+	cmd.Dir = filepath.Join("..", "..", "cmd", "rescodegen")
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("CLI failed with --allow-ok-code: %v\nOutput: %s", err, string(output))
+	}
+}
+
+func TestCLI_HeaderFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	inputFile := filepath.Join(tmpDir, "errors.yaml")
+	headerFile := filepath.Join(tmpDir, "LICENSE.header")
+	outputFile := filepath.Join(tmpDir, "errors_gen.go")
+
+	yamlContent := `- code: 20001
+  key: PolicyNotFound
+  message: Policy not found
+  http: 404
+  grpc: 5`
+
+	if err := os.WriteFile(inputFile, []byte(yamlContent), 0644); err != nil {
+		t.Fatalf("Failed to create test input file: %v", err)
+	}
+	if err := os.WriteFile(headerFile, []byte("Copyright 2026 Example Corp.\n"), 0644); err != nil {
+		t.Fatalf("Failed to create header file: %v", err)
+	}
+
+	cmd := exec.Command("go", "run", ".", "--input", inputFile, "--output", outputFile, "--package", "errs", "--header-file", headerFile)
+	cmd.Dir = filepath.Join("..", "..", "cmd", "rescodegen")
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, string(output))
+	}
+
+	content, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+
+	expected := "// Copyright 2026 Example Corp.\n\n// Code generated by rescodegen. DO NOT EDIT.\n"
+	if !strings.HasPrefix(string(content), expected) {
+		t.Errorf("Expected output to start with the header and DO NOT EDIT marker, got:\n%s", string(content))
+	}
+}
+
+func TestCLI_Perm(t *testing.T) {
+	tmpDir := t.TempDir()
+	inputFile := filepath.Join(tmpDir, "errors.yaml")
+	outputFile := filepath.Join(tmpDir, "errors_gen.go")
+
+	yamlContent := `- code: 20001
+  key: PolicyNotFound
+  message: Policy not found
+  http: 404
+  grpc: 5`
+
+	if err := os.WriteFile(inputFile, []byte(yamlContent), 0644); err != nil {
+		t.Fatalf("Failed to create test input file: %v", err)
+	}
+
+	for _, perm := range []os.FileMode{0600, 0664} {
+		cmd := exec.Command("go", "run", ".", "--input", inputFile, "--output", outputFile, "--package", "errs", "--perm", fmt.Sprintf("%04o", perm))
+		cmd.Dir = filepath.Join("..", "..", "cmd", "rescodegen")
+
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("CLI failed with --perm %04o: %v\nOutput: %s", perm, err, string(output))
+		}
+
+		info, err := os.Stat(outputFile)
+		if err != nil {
+			t.Fatalf("Failed to stat output file: %v", err)
+		}
+		if info.Mode().Perm() != perm {
+			t.Errorf("Expected mode %04o, got %04o", perm, info.Mode().Perm())
+		}
+	}
+
+	cmd := exec.Command("go", "run", ".", "--input", inputFile, "--output", outputFile, "--perm", "rw-r--r--")
+	cmd.Dir = filepath.Join("..", "..", "cmd", "rescodegen")
+
+	output, err := cmd.CombinedOutput()
+	if err == nil || !strings.Contains(string(output), "invalid --perm") {
+		t.Errorf("Expected an invalid --perm error, got %v: %s", err, string(output))
+	}
+}
+
+func TestCLI_Verify(t *testing.T) {
+	tmpDir := t.TempDir()
+	inputFile := filepath.Join(tmpDir, "errors.yaml")
+	outputFile := filepath.Join(tmpDir, "errors_gen.go")
+
+	yamlContent := `- code: 20001
+  key: PolicyNotFound
+  message: Policy not found
+  http: 404
+  grpc: 5`
+
+	if err := os.WriteFile(inputFile, []byte(yamlContent), 0644); err != nil {
+		t.Fatalf("Failed to create test input file: %v", err)
+	}
+
+	run := func(extra ...string) ([]byte, error) {
+		args := append([]string{"run", ".", "--input", inputFile, "--output", outputFile, "--package", "errs"}, extra...)
+		cmd := exec.Command("go", args...)
+		cmd.Dir = filepath.Join("..", "..", "cmd", "rescodegen")
+		return cmd.CombinedOutput()
+	}
+
+	if output, err := run(); err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, string(output))
+	}
+	if output, err := run("--verify"); err != nil {
+		t.Fatalf("Expected fresh output to verify: %v\nOutput: %s", err, string(output))
+	}
+
+	// Edit the input without regenerating
+	if err := os.WriteFile(inputFile, []byte(strings.Replace(yamlContent, "Policy not found", "Policy missing", 1)), 0644); err != nil {
+		t.Fatalf("Failed to update test input file: %v", err)
+	}
+	before, _ := os.ReadFile(outputFile)
+
+	output, err := run("--verify")
+	if err == nil {
+		t.Error("Expected --verify to fail for a stale output file")
+	}
+	if !strings.Contains(string(output), "out of date") || !strings.Contains(string(output), "Policy missing") {
+		t.Errorf("Expected a diff summary, got %s", string(output))
+	}
+
+	after, _ := os.ReadFile(outputFile)
+	if string(before) != string(after) {
+		t.Error("--verify should not overwrite the output file")
+	}
+}
+
+func TestWatch_RegeneratesOnChange(t *testing.T) {
+	tmpDir := t.TempDir()
+	inputFile := filepath.Join(tmpDir, "errors.yaml")
+	outputFile := filepath.Join(tmpDir, "errors_gen.go")
+
+	writeInput := func(key string) {
+		content := "- code: 31001\n  key: " + key + "\n  message: Test error message\n  http: 400\n  grpc: 3\n"
+		if err := os.WriteFile(inputFile, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write test input file: %v", err)
+		}
+	}
+	writeInput("FirstError")
+
+	opts := options{input: inputFile, output: outputFile, pkg: "testpkg"}
+	runs := make(chan error, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		watch(ctx, []string{inputFile}, 10*time.Millisecond, func() error {
+			err := generate(opts)
+			runs <- err
+			return err
+		}, io.Discard, io.Discard)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	waitForRun := func() {
+		select {
+		case err := <-runs:
+			if err != nil {
+				t.Fatalf("Regeneration failed: %v", err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("Timed out waiting for regeneration")
+		}
+	}
+
+	waitForRun()
+	content, err := os.ReadFile(outputFile)
+	if err != nil || !strings.Contains(string(content), "func FirstError(") {
+		t.Fatalf("Expected initial generation to contain FirstError, err: %v", err)
+	}
+
+	// Ensure the modification time differs on filesystems with coarse timestamps
+	time.Sleep(20 * time.Millisecond)
+	writeInput("SecondError")
+	future := time.Now().Add(time.Second)
+	if err := os.Chtimes(inputFile, future, future); err != nil {
+		t.Fatalf("Failed to update input modification time: %v", err)
+	}
+
+	waitForRun()
+	content, err = os.ReadFile(outputFile)
+	if err != nil || !strings.Contains(string(content), "func SecondError(") {
+		t.Errorf("Expected regenerated output to contain SecondError, err: %v", err)
+	}
+}
+
+func TestCLI_QuietAndVerbose(t *testing.T) {
+	tmpDir := t.TempDir()
+	inputFile := filepath.Join(tmpDir, "errors.yaml")
+	outputFile := filepath.Join(tmpDir, "errors_gen.go")
+
+	yamlContent := `- code: 20001
+  key: PolicyNotFound
+  message: Policy not found
+  http: 404
+  grpc: 5`
+
+	if err := os.WriteFile(inputFile, []byte(yamlContent), 0644); err != nil {
+		t.Fatalf("Failed to create test input file: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		flag    string
+		present []string
+		absent  []string
+	}{
+		{
+			name:    "default",
+			present: []string{"Successfully generated"},
+			absent:  []string{"Parsed", "Package:"},
+		},
+		{
+			name:   "quiet",
+			flag:   "--quiet",
+			absent: []string{"Successfully generated", "Parsed", "Package:"},
+		},
+		{
+			name: "verbose",
+			flag: "--verbose",
+			present: []string{
+				"Successfully generated",
+				"Parsed 1 error definitions from " + inputFile + " (format: YAML)",
+				"Package: errs",
+				"Writing " + outputFile,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := []string{"run", ".", "--input", inputFile, "--output", outputFile, "--package", "errs"}
+			if tt.flag != "" {
+				args = append(args, tt.flag)
+			}
+			cmd := exec.Command("go", args...)
+			cmd.Dir = filepath.Join("..", "..", "cmd", "rescodegen")
+
+			output, err := cmd.Output()
+			if err != nil {
+				t.Fatalf("CLI failed: %v\nOutput: %s", err, string(output))
+			}
+
+			for _, line := range tt.present {
+				if !strings.Contains(string(output), line) {
+					t.Errorf("Expected output to contain %q, got:\n%s", line, string(output))
+				}
+			}
+			for _, line := range tt.absent {
+				if strings.Contains(string(output), line) {
+					t.Errorf("Expected output not to contain %q, got:\n%s", line, string(output))
+				}
+			}
+		})
+	}
+}
+
+func TestCLI_EmitTestPasses(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping go test of generated code in short mode")
+	}
+
+	root, err := filepath.Abs(filepath.Join("..", ".."))
+	if err != nil {
+		t.Fatalf("Failed to resolve module root: %v", err)
+	}
+	modDir := t.TempDir()
+
+	// Build a module depending on this checkout, reusing its requirements
+	// and checksums so no download is needed
+	goMod, err := os.ReadFile(filepath.Join(root, "go.mod"))
+	if err != nil {
+		t.Fatalf("Failed to read go.mod: %v", err)
+	}
+	goMod = []byte(strings.Replace(string(goMod), "module github.com/restayway/rescode", "module example.com/errs", 1) +
+		"\nrequire github.com/restayway/rescode v0.0.0\n\nreplace github.com/restayway/rescode => " + root + "\n")
+	goSum, err := os.ReadFile(filepath.Join(root, "go.sum"))
+	if err != nil {
+		t.Fatalf("Failed to read go.sum: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(modDir, "go.mod"), goMod, 0644); err != nil {
+		t.Fatalf("Failed to write go.mod: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(modDir, "go.sum"), goSum, 0644); err != nil {
+		t.Fatalf("Failed to write go.sum: %v", err)
+	}
+
+	inputFile := filepath.Join(modDir, "errors.yaml")
+	yamlContent := `- code: 20001
+  key: PolicyNotFound
+  message: Policy not found
+  http: 404
+  grpc: 5
+- code: 20002
+  key: InvalidKind
+  message: Invalid policy kind
+  http: 400
+  grpc: 3
+- code: 20003
+  key: Accepted
+  message: Request accepted
+  http: 200`
+	if err := os.WriteFile(inputFile, []byte(yamlContent), 0644); err != nil {
+		t.Fatalf("Failed to create test input file: %v", err)
+	}
+
+	outputFile := filepath.Join(modDir, "errors_gen.go")
+	cmd := exec.Command("go", "run", ".", "--input", inputFile, "--output", outputFile, "--package", "errs",
+		"--emit-test", "--gen-grpc-roundtrip-test", "--allow-ok-code")
+	cmd.Dir = filepath.Join("..", "..", "cmd", "rescodegen")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("CLI failed: %v\nOutput: %s", err, string(output))
+	}
+
+	for _, name := range []string{"errors_gen_test.go", "errors_gen_grpc_roundtrip_test.go"} {
+		if _, err := os.Stat(filepath.Join(modDir, name)); err != nil {
+			t.Fatalf("Expected %s to be written: %v", name, err)
+		}
+	}
+
+	test := exec.Command("go", "test", "./...")
+	test.Dir = modDir
+	test.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off")
+	if output, err := test.CombinedOutput(); err != nil {
+		t.Fatalf("Generated test failed: %v\nOutput: %s", err, string(output))
+	}
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// verifyFile reports an error if the file at path does not hold exactly want,
+// summarizing the first difference.
+func verifyFile(path string, want []byte) error {
+	got, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return fmt.Errorf("%s is out of date: file does not exist", path)
+	}
+	if err != nil {
+		return fmt.Errorf("Failed to read output file %s: %v", path, err)
+	}
+
+	if bytes.Equal(got, want) {
+		return nil
+	}
+	return fmt.Errorf("%s is out of date (regenerate it with rescodegen):\n%s", path, diffSummary(got, want))
+}
+
+// diffSummary describes the first line at which got and want differ, along
+// with their line counts.
+func diffSummary(got, want []byte) string {
+	gotLines := strings.Split(string(got), "\n")
+	wantLines := strings.Split(string(want), "\n")
+
+	line := 0
+	for line < len(gotLines) && line < len(wantLines) && gotLines[line] == wantLines[line] {
+		line++
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "  first difference at line %d\n", line+1)
+	if line < len(gotLines) {
+		fmt.Fprintf(&b, "  - %s\n", gotLines[line])
+	}
+	if line < len(wantLines) {
+		fmt.Fprintf(&b, "  + %s\n", wantLines[line])
+	}
+	fmt.Fprintf(&b, "  existing file has %d lines, generated code has %d", len(gotLines), len(wantLines))
+	return b.String()
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// pollInterval is how often --watch checks the input files for changes.
+const pollInterval = 250 * time.Millisecond
+
+// fileState captures the attributes used to detect a change to a file.
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// statFile returns the current state of path, or the zero state if it
+// cannot be read (e.g. while an editor is replacing it).
+func statFile(path string) fileState {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileState{}
+	}
+	return fileState{modTime: info.ModTime(), size: info.Size()}
+}
+
+// statFiles returns the state of each of paths.
+func statFiles(paths []string) []fileState {
+	states := make([]fileState, len(paths))
+	for i, path := range paths {
+		states[i] = statFile(path)
+	}
+	return states
+}
+
+// watch runs regenerate once and then again whenever one of paths changes,
+// until ctx is cancelled. It polls every interval and debounces rapid
+// successive writes by waiting for the files to stay unchanged for one full
+// interval.
+// Each run prints a timestamped line to out; failures go to errOut and do
+// not stop the watch.
+func watch(ctx context.Context, paths []string, interval time.Duration, regenerate func() error, out, errOut io.Writer) {
+	run := func() {
+		stamp := time.Now().Format("15:04:05")
+		if err := regenerate(); err != nil {
+			fmt.Fprintf(errOut, "[%s] Error: %v\n", stamp, err)
+			return
+		}
+		fmt.Fprintf(out, "[%s] Regenerated from %s\n", stamp, strings.Join(paths, ", "))
+	}
+
+	run()
+
+	last := statFiles(paths)
+	pending := false
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			current := statFiles(paths)
+			if !reflect.DeepEqual(current, last) {
+				// Still changing; wait for it to settle
+				last = current
+				pending = true
+				continue
+			}
+			if pending {
+				pending = false
+				run()
+			}
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package rescode
+
+import "context"
+
+// rcContextKey is the unexported key under which an RC is stored in a context.
+type rcContextKey struct{}
+
+// WithRC returns a copy of ctx carrying rc. Only the last RC set is kept, so
+// a later call on a derived context shadows an earlier one.
+func WithRC(ctx context.Context, rc *RC) context.Context {
+	return context.WithValue(ctx, rcContextKey{}, rc)
+}
+
+// FromContext returns the RC most recently stored in ctx by WithRC, if any.
+func FromContext(ctx context.Context) (*RC, bool) {
+	rc, ok := ctx.Value(rcContextKey{}).(*RC)
+	return rc, ok && rc != nil
+}
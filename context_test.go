@@ -0,0 +1,34 @@
+package rescode
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+)
+
+func TestContext_SetGet(t *testing.T) {
+	first := New(1101, 400, codes.InvalidArgument, "first")()
+	second := New(1102, 404, codes.NotFound, "second")()
+
+	ctx := WithRC(context.Background(), first)
+	ctx = WithRC(ctx, second)
+
+	rc, ok := FromContext(ctx)
+	if !ok {
+		t.Fatal("Expected RC to be present in context")
+	}
+	if rc != second {
+		t.Errorf("Expected last set RC %v, got %v", second, rc)
+	}
+}
+
+func TestContext_NotPresent(t *testing.T) {
+	rc, ok := FromContext(context.Background())
+	if ok {
+		t.Error("Expected no RC in empty context")
+	}
+	if rc != nil {
+		t.Errorf("Expected nil RC, got %v", rc)
+	}
+}
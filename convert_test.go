@@ -0,0 +1,66 @@
+package rescode
+
+import (
+	"testing"
+
+	"google.golang.org/grpc/codes"
+)
+
+func TestGRPCFromHTTP(t *testing.T) {
+	tests := []struct {
+		status   int
+		expected codes.Code
+	}{
+		{200, codes.OK},
+		{400, codes.InvalidArgument},
+		{401, codes.Unauthenticated},
+		{403, codes.PermissionDenied},
+		{404, codes.NotFound},
+		{409, codes.AlreadyExists},
+		{429, codes.ResourceExhausted},
+		{499, codes.Canceled},
+		{500, codes.Internal},
+		{501, codes.Unimplemented},
+		{503, codes.Unavailable},
+		{504, codes.DeadlineExceeded},
+		{418, codes.Unknown}, // default fallback
+	}
+
+	for _, tt := range tests {
+		if got := GRPCFromHTTP(tt.status); got != tt.expected {
+			t.Errorf("GRPCFromHTTP(%d): expected %v, got %v", tt.status, tt.expected, got)
+		}
+	}
+}
+
+func TestHTTPFromGRPC(t *testing.T) {
+	tests := []struct {
+		code     codes.Code
+		expected int
+	}{
+		{codes.OK, 200},
+		{codes.Canceled, 499},
+		{codes.Unknown, 500},
+		{codes.InvalidArgument, 400},
+		{codes.DeadlineExceeded, 504},
+		{codes.NotFound, 404},
+		{codes.AlreadyExists, 409},
+		{codes.PermissionDenied, 403},
+		{codes.Unauthenticated, 401},
+		{codes.ResourceExhausted, 429},
+		{codes.FailedPrecondition, 400},
+		{codes.Aborted, 409},
+		{codes.OutOfRange, 400},
+		{codes.Unimplemented, 501},
+		{codes.Internal, 500},
+		{codes.Unavailable, 503},
+		{codes.DataLoss, 500},
+		{codes.Code(99), 500}, // default fallback
+	}
+
+	for _, tt := range tests {
+		if got := HTTPFromGRPC(tt.code); got != tt.expected {
+			t.Errorf("HTTPFromGRPC(%v): expected %d, got %d", tt.code, tt.expected, got)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package rescode
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// statusDetailDomain identifies the status detail attached by GRPCStatus
+// among any other google.protobuf.Struct details.
+const statusDetailDomain = "rescode"
+
+// statusDetail returns the detail message attached by GRPCStatus. It is a
+// google.protobuf.Struct, so clients need no extra proto definitions:
+//
+//	{"domain": "rescode", "code": "20001", "data": {...}}
+//
+// The code is a decimal string so 64-bit codes survive JSON-style number
+// handling, and data is Data as encoded by encoding/json.
+func (r *RC) statusDetail() (*structpb.Struct, error) {
+	fields := map[string]any{
+		"domain": statusDetailDomain,
+		"code":   strconv.FormatUint(r.Code, 10),
+	}
+
+	if data, _ := r.guarded(); data != nil {
+		encoded, err := json.Marshal(data)
+		if err != nil {
+			return nil, err
+		}
+		var decoded any
+		if err := json.Unmarshal(encoded, &decoded); err != nil {
+			return nil, err
+		}
+		fields["data"] = decoded
+	}
+
+	return structpb.NewStruct(fields)
+}
+
+// StatusDetail extracts the code and data attached to st by GRPCStatus, for
+// clients that receive an RC as a gRPC error. Data is decoded as by
+// encoding/json into an any (objects become map[string]any). ok is false if
+// st carries no rescode detail.
+func StatusDetail(st *status.Status) (code uint64, data any, ok bool) {
+	for _, detail := range st.Details() {
+		s, isStruct := detail.(*structpb.Struct)
+		if !isStruct || s.Fields["domain"].GetStringValue() != statusDetailDomain {
+			continue
+		}
+
+		code, err := strconv.ParseUint(s.Fields["code"].GetStringValue(), 10, 64)
+		if err != nil {
+			continue
+		}
+		if value, exists := s.Fields["data"]; exists {
+			data = value.AsInterface()
+		}
+		return code, data, true
+	}
+	return 0, nil, false
+}
+
+// FromGRPCStatus rebuilds an RC from a gRPC status, the client-side inverse of
+// GRPCStatus. The code and Data come from the rescode detail when present,
+// the message is the status message, and HttpCode is derived from the gRPC
+// code with HTTPFromGRPC. It returns nil for a nil status or codes.OK.
+func FromGRPCStatus(st *status.Status) *RC {
+	if st == nil || st.Code() == codes.OK {
+		return nil
+	}
+
+	rc := &RC{
+		Message:  st.Message(),
+		HttpCode: HTTPFromGRPC(st.Code()),
+		RpcCode:  st.Code(),
+	}
+	if code, data, ok := StatusDetail(st); ok {
+		rc.Code, rc.Data = code, data
+	}
+	return rc
+}
@@ -0,0 +1,90 @@
+package rescode
+
+import (
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestGRPCStatus_Details(t *testing.T) {
+	rc := New(20001, 404, codes.NotFound, "Policy not found")(errors.New("no rows"))
+	rc.SetData(map[string]any{"id": "p1", "attempts": 2})
+
+	st := status.Convert(rc)
+	if st.Code() != codes.NotFound {
+		t.Errorf("Expected NotFound, got %v", st.Code())
+	}
+
+	details := st.Details()
+	if len(details) != 1 {
+		t.Fatalf("Expected 1 detail, got %d", len(details))
+	}
+	if _, ok := details[0].(*structpb.Struct); !ok {
+		t.Fatalf("Expected a google.protobuf.Struct detail, got %T", details[0])
+	}
+
+	// Round-trip through the wire representation
+	st = status.FromProto(st.Proto())
+	code, data, ok := StatusDetail(st)
+	if !ok {
+		t.Fatal("Expected a rescode detail")
+	}
+	if code != 20001 {
+		t.Errorf("Expected code 20001, got %d", code)
+	}
+	m, isMap := data.(map[string]any)
+	if !isMap || m["id"] != "p1" || m["attempts"] != float64(2) {
+		t.Errorf("Expected data to round-trip, got %v", data)
+	}
+}
+
+func TestGRPCStatus_DetailsWithoutData(t *testing.T) {
+	rc := New(20002, 400, codes.InvalidArgument, "Invalid kind")()
+
+	code, data, ok := StatusDetail(rc.GRPCStatus())
+	if !ok || code != 20002 || data != nil {
+		t.Errorf("Expected code 20002 without data, got %d, %v, %v", code, data, ok)
+	}
+}
+
+func TestStatusDetail_Missing(t *testing.T) {
+	if _, _, ok := StatusDetail(status.New(codes.Internal, "plain")); ok {
+		t.Error("Expected no rescode detail on a plain status")
+	}
+
+	// Data that cannot be encoded leaves the status without details
+	rc := New(20003, 500, codes.Internal, "Internal")().SetData(func() {})
+	if _, _, ok := StatusDetail(rc.GRPCStatus()); ok {
+		t.Error("Expected no detail when Data cannot be encoded")
+	}
+	if rc.GRPCStatus().Code() != codes.Internal {
+		t.Error("Expected the status code to be kept without details")
+	}
+}
+
+func TestFromGRPCStatus(t *testing.T) {
+	rc := New(20001, 404, codes.NotFound, "Policy not found")()
+	rc.SetData(map[string]any{"id": "p1"})
+
+	got := FromGRPCStatus(status.FromProto(rc.GRPCStatus().Proto()))
+	if got.Code != 20001 || got.Message != "Policy not found" || got.RpcCode != codes.NotFound || got.HttpCode != 404 {
+		t.Errorf("Expected PolicyNotFound to round-trip, got %v", got)
+	}
+	if m, ok := got.Data.(map[string]any); !ok || m["id"] != "p1" {
+		t.Errorf("Expected data to round-trip, got %v", got.Data)
+	}
+}
+
+func TestFromGRPCStatus_Plain(t *testing.T) {
+	got := FromGRPCStatus(status.New(codes.Unavailable, "try later"))
+	if got.Code != 0 || got.Message != "try later" || got.HttpCode != 503 || got.Data != nil {
+		t.Errorf("Expected a code-less RC from a plain status, got %v", got)
+	}
+
+	if FromGRPCStatus(nil) != nil || FromGRPCStatus(status.New(codes.OK, "")) != nil {
+		t.Error("Expected nil for a nil or OK status")
+	}
+}
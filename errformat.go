@@ -0,0 +1,26 @@
+package rescode
+
+import "sync/atomic"
+
+var originalErrorFormatter atomic.Pointer[func(error) string]
+
+// SetOriginalErrorFormatter sets the function JSON, MarshalJSON and LogValue
+// use to render the wrapped error as originalError, for example to redact
+// personal data a cause may contain. Passing nil restores the default,
+// err.Error(). It is safe for concurrent use but is intended to be called
+// once during initialization.
+func SetOriginalErrorFormatter(format func(error) string) {
+	if format == nil {
+		originalErrorFormatter.Store(nil)
+		return
+	}
+	originalErrorFormatter.Store(&format)
+}
+
+// formatOriginalError renders err with the configured formatter.
+func formatOriginalError(err error) string {
+	if format := originalErrorFormatter.Load(); format != nil {
+		return (*format)(err)
+	}
+	return err.Error()
+}
@@ -0,0 +1,38 @@
+package rescode
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+)
+
+func TestSetOriginalErrorFormatter(t *testing.T) {
+	t.Cleanup(func() { SetOriginalErrorFormatter(nil) })
+
+	rc := New(1301, 500, codes.Internal, "storage failed")(errors.New("user alice@example.com not found"))
+	SetOriginalErrorFormatter(func(err error) string { return strings.ToUpper(err.Error()) })
+
+	if got := rc.JSON()["originalError"]; got != "USER ALICE@EXAMPLE.COM NOT FOUND" {
+		t.Errorf("Expected the formatted originalError in JSON, got %v", got)
+	}
+
+	data, err := json.Marshal(rc)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	if !strings.Contains(string(data), `"originalError":"USER ALICE@EXAMPLE.COM NOT FOUND"`) {
+		t.Errorf("Expected the formatted originalError in MarshalJSON, got %s", data)
+	}
+
+	if rc.OriginalError().Error() != "user alice@example.com not found" {
+		t.Errorf("Expected the wrapped error to be untouched, got %v", rc.OriginalError())
+	}
+
+	SetOriginalErrorFormatter(nil)
+	if got := rc.JSON()["originalError"]; got != "user alice@example.com not found" {
+		t.Errorf("Expected the default formatter after reset, got %v", got)
+	}
+}
@@ -0,0 +1,189 @@
+package rescode
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"google.golang.org/grpc/codes"
+)
+
+// FromJSON rebuilds an RC from the map produced by JSON, for example after it
+// has been sent over the wire. Key names follow SetJSONKeys. The code,
+// message, httpCode and rpcCode keys are required; data, service, suggestion,
+// uuid, caller and tags are optional, and originalError is restored as a plain error
+// with the same text. Numbers may be any integer type, float64 with an
+// integral value, or json.Number. json.Number values inside data are
+// converted to float64, as encoding/json decodes numbers into an any.
+func FromJSON(m map[string]any) (*RC, error) {
+	names := currentJSONKeys()
+
+	code, err := jsonUint(m, names.Code)
+	if err != nil {
+		return nil, err
+	}
+	httpCode, err := jsonUint(m, names.HTTPCode)
+	if err != nil {
+		return nil, err
+	}
+	rpcCode, err := jsonUint(m, names.RPCCode)
+	if err != nil {
+		return nil, err
+	}
+	message, err := jsonString(m, names.Message, true)
+	if err != nil {
+		return nil, err
+	}
+
+	rc := &RC{
+		Code:     code,
+		Message:  message,
+		HttpCode: int(httpCode),
+		RpcCode:  codes.Code(rpcCode),
+		Data:     normalizeJSONNumbers(m[names.Data]),
+	}
+
+	if rc.Service, err = jsonString(m, names.Service, false); err != nil {
+		return nil, err
+	}
+	if rc.Suggestion, err = jsonString(m, names.Suggestion, false); err != nil {
+		return nil, err
+	}
+	if rc.UUID, err = jsonString(m, names.UUID, false); err != nil {
+		return nil, err
+	}
+	if rc.caller, err = jsonString(m, names.Caller, false); err != nil {
+		return nil, err
+	}
+	if rc.Tags, err = jsonTags(m, names.Tags); err != nil {
+		return nil, err
+	}
+	original, err := jsonString(m, names.OriginalError, false)
+	if err != nil {
+		return nil, err
+	}
+	if original != "" {
+		rc.err = errors.New(original)
+	}
+
+	return rc, nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler using FromJSON, so an RC encoded
+// by MarshalJSON can be decoded again.
+func (r *RC) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var m map[string]any
+	if err := dec.Decode(&m); err != nil {
+		return err
+	}
+
+	rc, err := FromJSON(m)
+	if err != nil {
+		return err
+	}
+	*r = *rc
+	return nil
+}
+
+// jsonUint reads a required non-negative integer from m.
+func jsonUint(m map[string]any, key string) (uint64, error) {
+	value, ok := m[key]
+	if !ok {
+		return 0, fmt.Errorf("rescode: missing required key %q", key)
+	}
+
+	switch v := value.(type) {
+	case uint64:
+		return v, nil
+	case int:
+		if v >= 0 {
+			return uint64(v), nil
+		}
+	case int64:
+		if v >= 0 {
+			return uint64(v), nil
+		}
+	case uint32:
+		return uint64(v), nil
+	case float64:
+		if v >= 0 && v == math.Trunc(v) && v <= math.MaxUint64 {
+			return uint64(v), nil
+		}
+	case json.Number:
+		if n, err := strconv.ParseUint(v.String(), 10, 64); err == nil {
+			return n, nil
+		}
+	}
+	return 0, fmt.Errorf("rescode: key %q must be a non-negative integer, got %v", key, value)
+}
+
+// jsonString reads a string from m, which must be present if required.
+func jsonString(m map[string]any, key string, required bool) (string, error) {
+	value, ok := m[key]
+	if !ok {
+		if required {
+			return "", fmt.Errorf("rescode: missing required key %q", key)
+		}
+		return "", nil
+	}
+
+	s, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("rescode: key %q must be a string, got %T", key, value)
+	}
+	return s, nil
+}
+
+// jsonTags reads an optional object of string values from m, as produced by
+// JSON or decoded by encoding/json.
+func jsonTags(m map[string]any, key string) (map[string]string, error) {
+	switch value := m[key].(type) {
+	case nil:
+		return nil, nil
+	case map[string]string:
+		tags := make(map[string]string, len(value))
+		for k, v := range value {
+			tags[k] = v
+		}
+		return tags, nil
+	case map[string]any:
+		tags := make(map[string]string, len(value))
+		for k, v := range value {
+			s, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("rescode: tag %q must be a string, got %T", k, v)
+			}
+			tags[k] = s
+		}
+		return tags, nil
+	default:
+		return nil, fmt.Errorf("rescode: key %q must be an object, got %T", key, value)
+	}
+}
+
+// normalizeJSONNumbers replaces json.Number values in v, including inside
+// nested objects and arrays, with float64.
+func normalizeJSONNumbers(v any) any {
+	switch v := v.(type) {
+	case json.Number:
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+		return v.String()
+	case map[string]any:
+		for k, elem := range v {
+			v[k] = normalizeJSONNumbers(elem)
+		}
+	case []any:
+		for i, elem := range v {
+			v[i] = normalizeJSONNumbers(elem)
+		}
+	}
+	return v
+}
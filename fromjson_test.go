@@ -0,0 +1,112 @@
+package rescode
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+)
+
+func TestFromJSON_RoundTrip(t *testing.T) {
+	rc := New(20001, 404, codes.NotFound, "Policy not found")(errors.New("no rows"))
+	rc.SetData(map[string]string{"id": "p1"})
+	rc.WithSuggestion("Check the ID")
+
+	decoded, err := FromJSON(rc.JSON())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !decoded.Equal(rc) {
+		t.Errorf("Expected %v, got %v", rc, decoded)
+	}
+	if decoded.Suggestion != "Check the ID" {
+		t.Errorf("Expected suggestion to round-trip, got %q", decoded.Suggestion)
+	}
+}
+
+func TestRC_UnmarshalJSON(t *testing.T) {
+	rc := New(20001, 404, codes.NotFound, "Policy not found")(errors.New("no rows"))
+	rc.WithTag("tenant", "acme")
+
+	data, err := json.Marshal(rc)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+
+	var decoded RC
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to unmarshal: %v", err)
+	}
+	if !decoded.Equal(rc) {
+		t.Errorf("Expected %v, got %v", rc, &decoded)
+	}
+	if decoded.Tags["tenant"] != "acme" {
+		t.Errorf("Expected tags to round-trip, got %v", decoded.Tags)
+	}
+}
+
+func TestRC_UnmarshalJSON_DataNumbers(t *testing.T) {
+	var decoded RC
+	body := `{"code":20001,"message":"m","httpCode":404,"rpcCode":5,"data":{"limit":10,"ratio":[0.5,2]}}`
+	if err := json.Unmarshal([]byte(body), &decoded); err != nil {
+		t.Fatalf("Failed to unmarshal: %v", err)
+	}
+
+	data, ok := decoded.Data.(map[string]any)
+	if !ok {
+		t.Fatalf("Expected map data, got %T", decoded.Data)
+	}
+	if data["limit"] != float64(10) {
+		t.Errorf("Expected limit float64(10), got %#v", data["limit"])
+	}
+	if ratio, _ := data["ratio"].([]any); len(ratio) != 2 || ratio[0] != 0.5 {
+		t.Errorf("Expected ratio [0.5 2], got %#v", data["ratio"])
+	}
+}
+
+func TestRC_UnmarshalJSON_FractionalCode(t *testing.T) {
+	var decoded RC
+	body := `{"code":20001.9,"message":"m","httpCode":404,"rpcCode":5}`
+	if err := json.Unmarshal([]byte(body), &decoded); err == nil {
+		t.Errorf("Expected an error for a fractional code, got code %d", decoded.Code)
+	}
+}
+
+func TestFromJSON_Errors(t *testing.T) {
+	valid := func() map[string]any {
+		return map[string]any{"code": 1, "message": "m", "httpCode": 400, "rpcCode": 3}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(m map[string]any)
+	}{
+		{"missing code", func(m map[string]any) { delete(m, "code") }},
+		{"missing message", func(m map[string]any) { delete(m, "message") }},
+		{"missing httpCode", func(m map[string]any) { delete(m, "httpCode") }},
+		{"string code", func(m map[string]any) { m["code"] = "1" }},
+		{"fractional httpCode", func(m map[string]any) { m["httpCode"] = 400.5 }},
+		{"negative rpcCode", func(m map[string]any) { m["rpcCode"] = -1 }},
+		{"fractional json.Number code", func(m map[string]any) { m["code"] = json.Number("20001.9") }},
+		{"json.Number code with suffix", func(m map[string]any) { m["code"] = json.Number("404abc") }},
+		{"numeric message", func(m map[string]any) { m["message"] = 1 }},
+		{"numeric originalError", func(m map[string]any) { m["originalError"] = 1 }},
+		{"string tags", func(m map[string]any) { m["tags"] = "tenant=acme" }},
+		{"numeric tag value", func(m map[string]any) { m["tags"] = map[string]any{"shard": 1} }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := valid()
+			tt.modify(m)
+			if _, err := FromJSON(m); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+
+	if _, err := FromJSON(valid()); err != nil {
+		t.Errorf("Expected valid map to decode, got %v", err)
+	}
+}
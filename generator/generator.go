@@ -0,0 +1,63 @@
+// Package generator exposes the rescodegen input parser and code generator
+// to build tools and other programs that work with error definition files
+// without running the command.
+package generator
+
+import (
+	"io"
+
+	"github.com/restayway/rescode/internal/generator"
+)
+
+// ErrorDefinition represents a single error definition from the input file.
+type ErrorDefinition = generator.ErrorDefinition
+
+// Config holds the configuration for code generation.
+type Config = generator.Config
+
+// Deprecation marks an error definition as deprecated, written in input files
+// as a boolean or a reason string.
+type Deprecation = generator.Deprecation
+
+// Summary holds statistics about a set of error definitions.
+type Summary = generator.Summary
+
+// CodeRange is an inclusive range of codes.
+type CodeRange = generator.CodeRange
+
+// ParseInput reads and parses the input file (YAML, JSON or .proto) into error
+// definitions, detecting the format from filename.
+func ParseInput(reader io.Reader, filename string) ([]ErrorDefinition, error) {
+	return generator.ParseInput(reader, filename)
+}
+
+// ParseInputBytes parses raw input into error definitions. The formatHint may
+// be a filename ("errors.yaml"), an extension (".json") or a bare format name
+// ("yaml"); when empty or unrecognized the format is auto-detected.
+func ParseInputBytes(data []byte, formatHint string) ([]ErrorDefinition, error) {
+	return generator.ParseInputBytes(data, formatHint)
+}
+
+// ParseInputFile opens and parses the file at path, detecting the format from
+// its extension.
+func ParseInputFile(path string) ([]ErrorDefinition, error) {
+	return generator.ParseInputFile(path)
+}
+
+// Generate creates formatted Go source code from the error definitions.
+func Generate(config Config) ([]byte, error) {
+	return generator.Generate(config)
+}
+
+// GenerateToWriter generates Go source code like Generate and writes it to w.
+// Formatting needs the complete source, so the output is assembled in memory
+// rather than streamed; nothing is written if generation fails.
+func GenerateToWriter(config Config, w io.Writer) error {
+	return generator.GenerateToWriter(config, w)
+}
+
+// Summarize computes statistics over errors: counts by HTTP status and gRPC
+// code, the lowest and highest code, and the gaps in the code sequence.
+func Summarize(errors []ErrorDefinition) Summary {
+	return generator.Summarize(errors)
+}
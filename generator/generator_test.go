@@ -0,0 +1,107 @@
+package generator_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/restayway/rescode/generator"
+)
+
+const yamlInput = "- code: 20001\n  key: PolicyNotFound\n  message: Policy not found\n  http: 404\n"
+
+func TestParseInput(t *testing.T) {
+	errors, err := generator.ParseInput(strings.NewReader(yamlInput), "errors.yaml")
+	if err != nil {
+		t.Fatalf("Failed to parse input: %v", err)
+	}
+	if len(errors) != 1 || errors[0].Key != "PolicyNotFound" || errors[0].GRPC != 5 {
+		t.Errorf("Expected PolicyNotFound with inferred gRPC 5, got %+v", errors)
+	}
+}
+
+func TestParseInputBytes(t *testing.T) {
+	jsonInput := []byte(`[{"code": 20001, "key": "PolicyNotFound", "message": "Policy not found", "http": 404}]`)
+
+	for _, data := range [][]byte{jsonInput, []byte(yamlInput)} {
+		errors, err := generator.ParseInputBytes(data, "")
+		if err != nil {
+			t.Fatalf("Failed to parse input: %v", err)
+		}
+		if len(errors) != 1 || errors[0].Code != 20001 {
+			t.Errorf("Expected single definition with code 20001, got %+v", errors)
+		}
+	}
+}
+
+func TestParseInputFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "errors.yaml")
+	if err := os.WriteFile(path, []byte(yamlInput), 0644); err != nil {
+		t.Fatalf("Failed to write input file: %v", err)
+	}
+
+	errors, err := generator.ParseInputFile(path)
+	if err != nil {
+		t.Fatalf("Failed to parse input file: %v", err)
+	}
+	if len(errors) != 1 || errors[0].Message != "Policy not found" {
+		t.Errorf("Expected PolicyNotFound, got %+v", errors)
+	}
+}
+
+func TestGenerateToWriter(t *testing.T) {
+	defs, err := generator.ParseInputBytes([]byte(yamlInput), "yaml")
+	if err != nil {
+		t.Fatalf("Failed to parse input: %v", err)
+	}
+	config := generator.Config{Package: "errs", Errors: defs}
+
+	expected, err := generator.Generate(config)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := generator.GenerateToWriter(config, &buf); err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), expected) {
+		t.Error("Expected GenerateToWriter to write the output of Generate")
+	}
+	if !strings.Contains(buf.String(), "func PolicyNotFound(err ...error) *rescode.RC {") {
+		t.Error("Expected the PolicyNotFound factory in the output")
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestGenerateToWriter_Errors(t *testing.T) {
+	if err := generator.GenerateToWriter(generator.Config{Errors: []generator.ErrorDefinition{{Key: "A"}, {Key: "A"}}}, &bytes.Buffer{}); err == nil {
+		t.Error("Expected an error for invalid definitions")
+	}
+
+	defs, _ := generator.ParseInputBytes([]byte(yamlInput), "yaml")
+	if err := generator.GenerateToWriter(generator.Config{Errors: defs}, failingWriter{}); err == nil || !strings.Contains(err.Error(), "disk full") {
+		t.Errorf("Expected the write error, got %v", err)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	defs, err := generator.ParseInputBytes([]byte(yamlInput+"- code: 20003\n  key: RuleNotFound\n  message: Rule not found\n  http: 404\n"), "yaml")
+	if err != nil {
+		t.Fatalf("Failed to parse input: %v", err)
+	}
+
+	summary := generator.Summarize(defs)
+	if summary.Count != 2 || summary.ByHTTP[404] != 2 {
+		t.Errorf("Expected 2 definitions with HTTP 404, got %+v", summary)
+	}
+	if len(summary.Gaps) != 1 || summary.Gaps[0] != (generator.CodeRange{Min: 20002, Max: 20002}) {
+		t.Errorf("Expected a single gap at 20002, got %v", summary.Gaps)
+	}
+}
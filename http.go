@@ -0,0 +1,116 @@
+package rescode
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// WriteText writes the error as a plain-text response in the style of
+// http.Error: the status is HttpCode and the body is the message followed by
+// a newline. The wrapped error is not included.
+func (r *RC) WriteText(w http.ResponseWriter) {
+	h := w.Header()
+	h.Del("Content-Length")
+	h.Set("Content-Type", "text/plain; charset=utf-8")
+	h.Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(r.HttpCode)
+	fmt.Fprintln(w, r.Message)
+}
+
+// WriteHTTP writes Response as an application/json response with HttpCode as
+// the status. The wrapped error is not included.
+func (r *RC) WriteHTTP(w http.ResponseWriter) error {
+	body, err := json.Marshal(r.Response())
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(r.HttpCode)
+	_, err = w.Write(body)
+	return err
+}
+
+// Error replies to the request with rc in the style of http.Error, but with
+// JSON(keys...) as an application/json body and HttpCode as the status.
+// Without keys the body is the JSON of Public, so the wrapped error is only
+// sent when keys ask for originalError. If the body cannot be encoded, a
+// plain 500 is written instead.
+func Error(w http.ResponseWriter, rc *RC, keys ...string) {
+	var fields map[string]interface{}
+	if len(keys) == 0 {
+		fields = rc.Public().JSON()
+	} else {
+		fields = rc.JSON(keys...)
+	}
+	body, err := json.Marshal(fields)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	h := w.Header()
+	h.Del("Content-Length")
+	h.Set("Content-Type", "application/json")
+	h.Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(rc.HttpCode)
+	w.Write(append(body, '\n'))
+}
+
+// RecoverMiddleware recovers panics from next. A panic with an *RC is
+// written with WriteHTTP, falling back to a plain 500 if its body cannot be
+// encoded; any other value is re-panicked so net/http and outer middleware
+// handle it as before.
+func RecoverMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		defer func() {
+			v := recover()
+			if v == nil {
+				return
+			}
+			rc, ok := v.(*RC)
+			if !ok {
+				panic(v)
+			}
+			if err := rc.WriteHTTP(w); err != nil {
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			}
+		}()
+		next.ServeHTTP(w, req)
+	})
+}
+
+// RetryableHTTPStatuses is the set of HTTP status codes IsRetryableHTTP treats
+// as transient. It may be replaced at init to suit a particular upstream.
+var RetryableHTTPStatuses = map[int]bool{
+	http.StatusRequestTimeout:      true,
+	http.StatusTooManyRequests:     true,
+	http.StatusInternalServerError: true,
+	http.StatusBadGateway:          true,
+	http.StatusServiceUnavailable:  true,
+	http.StatusGatewayTimeout:      true,
+}
+
+// IsRetryableHTTP reports whether HttpCode is in RetryableHTTPStatuses, for
+// deciding whether a request that produced this error may be retried.
+func (r *RC) IsRetryableHTTP() bool {
+	return RetryableHTTPStatuses[r.HttpCode]
+}
+
+// CacheableHTTPStatuses is the set of HTTP status codes Cacheable treats as
+// deterministic, so the same request will keep failing the same way. It
+// defaults to the client errors RFC 9110 marks as heuristically cacheable
+// and may be replaced at init.
+var CacheableHTTPStatuses = map[int]bool{
+	http.StatusNotFound:          true,
+	http.StatusMethodNotAllowed:  true,
+	http.StatusGone:              true,
+	http.StatusRequestURITooLong: true,
+}
+
+// Cacheable reports whether HttpCode is in CacheableHTTPStatuses, for
+// deciding whether a CDN or cache may store the error response.
+func (r *RC) Cacheable() bool {
+	return CacheableHTTPStatuses[r.HttpCode]
+}
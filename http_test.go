@@ -0,0 +1,232 @@
+package rescode
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+)
+
+func TestRC_WriteText(t *testing.T) {
+	rc := New(1601, 404, codes.NotFound, "Policy not found")(errors.New("no rows"))
+	rec := httptest.NewRecorder()
+
+	rc.WriteText(rec)
+
+	if rec.Code != 404 {
+		t.Errorf("Expected status 404, got %d", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "text/plain; charset=utf-8" {
+		t.Errorf("Expected text/plain content type, got %q", ct)
+	}
+	if rec.Body.String() != "Policy not found\n" {
+		t.Errorf("Expected body 'Policy not found\\n', got %q", rec.Body.String())
+	}
+}
+
+func TestRC_WriteHTTP(t *testing.T) {
+	rc := New(1601, 404, codes.NotFound, "Policy not found", "policy-1")(errors.New("no rows"))
+	rec := httptest.NewRecorder()
+
+	if err := rc.WriteHTTP(rec); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if rec.Code != 404 {
+		t.Errorf("Expected status 404, got %d", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Expected application/json content type, got %q", ct)
+	}
+	expected := `{"code":1601,"message":"Policy not found","data":"policy-1"}`
+	if rec.Body.String() != expected {
+		t.Errorf("Expected body %s, got %s", expected, rec.Body.String())
+	}
+}
+
+func TestRecoverMiddleware(t *testing.T) {
+	handler := RecoverMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(New(1601, 404, codes.NotFound, "Policy not found")(errors.New("no rows")))
+	}))
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/policies/1", nil))
+
+	if rec.Code != 404 {
+		t.Errorf("Expected status 404, got %d", rec.Code)
+	}
+	expected := `{"code":1601,"message":"Policy not found"}`
+	if rec.Body.String() != expected {
+		t.Errorf("Expected body %s, got %s", expected, rec.Body.String())
+	}
+}
+
+func TestRecoverMiddleware_UnencodableData(t *testing.T) {
+	handler := RecoverMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(New(1601, 404, codes.NotFound, "Policy not found", func() {})())
+	}))
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status 500, got %d", rec.Code)
+	}
+	if rec.Body.String() != "Internal Server Error\n" {
+		t.Errorf("Expected body 'Internal Server Error\\n', got %q", rec.Body.String())
+	}
+}
+
+func TestRecoverMiddleware_RepanicsOtherValues(t *testing.T) {
+	handler := RecoverMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	defer func() {
+		if v := recover(); v != "boom" {
+			t.Errorf("Expected panic 'boom' to propagate, got %v", v)
+		}
+	}()
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
+
+func TestRecoverMiddleware_PassesThrough(t *testing.T) {
+	handler := RecoverMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("Expected status 204, got %d", rec.Code)
+	}
+}
+
+func TestRC_IsRetryableHTTP(t *testing.T) {
+	tests := []struct {
+		httpCode int
+		expected bool
+	}{
+		{503, true},
+		{429, true},
+		{408, true},
+		{404, false},
+		{400, false},
+	}
+
+	for _, tt := range tests {
+		rc := New(1001, tt.httpCode, codes.Unknown, "Test")()
+		if got := rc.IsRetryableHTTP(); got != tt.expected {
+			t.Errorf("Expected IsRetryableHTTP() %v for HTTP %d, got %v", tt.expected, tt.httpCode, got)
+		}
+	}
+}
+
+func TestRC_IsRetryableHTTP_CustomSet(t *testing.T) {
+	original := RetryableHTTPStatuses
+	t.Cleanup(func() { RetryableHTTPStatuses = original })
+
+	RetryableHTTPStatuses = map[int]bool{409: true}
+
+	if !New(1001, 409, codes.Aborted, "Conflict")().IsRetryableHTTP() {
+		t.Error("Expected 409 to be retryable with the custom set")
+	}
+	if New(1001, 503, codes.Unavailable, "Unavailable")().IsRetryableHTTP() {
+		t.Error("Expected 503 not to be retryable with the custom set")
+	}
+}
+
+func TestRC_Cacheable(t *testing.T) {
+	tests := []struct {
+		httpCode int
+		expected bool
+	}{
+		{404, true},
+		{410, true},
+		{400, false},
+		{429, false},
+		{500, false},
+		{503, false},
+	}
+
+	for _, tt := range tests {
+		rc := New(1001, tt.httpCode, codes.Unknown, "Test")()
+		if got := rc.Cacheable(); got != tt.expected {
+			t.Errorf("Expected Cacheable() %v for HTTP %d, got %v", tt.expected, tt.httpCode, got)
+		}
+	}
+}
+
+func TestRC_Cacheable_CustomSet(t *testing.T) {
+	original := CacheableHTTPStatuses
+	t.Cleanup(func() { CacheableHTTPStatuses = original })
+
+	CacheableHTTPStatuses = map[int]bool{400: true}
+
+	if !New(1001, 400, codes.InvalidArgument, "Bad request")().Cacheable() {
+		t.Error("Expected 400 to be cacheable with the custom set")
+	}
+	if New(1001, 404, codes.NotFound, "Not found")().Cacheable() {
+		t.Error("Expected 404 not to be cacheable with the custom set")
+	}
+}
+
+func TestError(t *testing.T) {
+	rc := New(1602, 409, codes.AlreadyExists, "Policy exists")(errors.New("duplicate key"))
+	rec := httptest.NewRecorder()
+	rec.Header().Set("Content-Length", "3")
+
+	Error(rec, rc, "code", "message")
+
+	if rec.Code != 409 {
+		t.Errorf("Expected status 409, got %d", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Expected application/json content type, got %q", ct)
+	}
+	if rec.Header().Get("Content-Length") != "" {
+		t.Error("Expected a stale Content-Length to be removed")
+	}
+	if body := rec.Body.String(); body != `{"code":1602,"message":"Policy exists"}`+"\n" {
+		t.Errorf("Expected filtered JSON body, got %q", body)
+	}
+
+	rec = httptest.NewRecorder()
+	Error(rec, rc)
+
+	var body map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Failed to decode body: %v", err)
+	}
+	if _, exists := body["originalError"]; exists {
+		t.Errorf("Expected no originalError without keys, got %v", body)
+	}
+	if body["message"] != "Policy exists" || body["httpCode"] != float64(409) {
+		t.Errorf("Expected the public JSON body without keys, got %v", body)
+	}
+
+	rec = httptest.NewRecorder()
+	Error(rec, rc, "code", "originalError")
+
+	if body := rec.Body.String(); body != `{"code":1602,"originalError":"duplicate key"}`+"\n" {
+		t.Errorf("Expected the cause when requested, got %q", body)
+	}
+}
+
+func TestError_UnencodableData(t *testing.T) {
+	rc := New(1603, 400, codes.InvalidArgument, "Bad input", func() {})()
+	rec := httptest.NewRecorder()
+
+	Error(rec, rc)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status 500, got %d", rec.Code)
+	}
+	if rec.Body.String() != "Internal Server Error\n" {
+		t.Errorf("Expected plain 500 body, got %q", rec.Body.String())
+	}
+}
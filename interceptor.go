@@ -0,0 +1,33 @@
+package rescode
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryServerInterceptor returns a gRPC interceptor that converts an *RC
+// returned by a handler, directly or wrapped, into its GRPCStatus error so
+// clients see the RC's code, message and details. An RC with codes.OK is
+// reported as codes.Unknown, since an OK status would turn the handler's
+// error into success. Other errors and responses pass through unchanged.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+
+		var rc *RC
+		if errors.As(err, &rc) {
+			if rc.RpcCode == codes.OK {
+				return resp, status.New(codes.Unknown, rc.Message).Err()
+			}
+			return resp, rc.GRPCStatus().Err()
+		}
+		return resp, err
+	}
+}
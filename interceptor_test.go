@@ -0,0 +1,91 @@
+package rescode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func invokeInterceptor(err error) (any, error) {
+	handler := func(ctx context.Context, req any) (any, error) {
+		return "resp", err
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: "/policy.v1.PolicyService/GetPolicy"}
+	return UnaryServerInterceptor()(context.Background(), "req", info, handler)
+}
+
+func TestUnaryServerInterceptor_ConvertsRC(t *testing.T) {
+	rc := New(20001, 404, codes.NotFound, "Policy not found", map[string]any{"id": "p1"})()
+
+	for _, err := range []error{rc, fmt.Errorf("get policy: %w", rc)} {
+		_, got := invokeInterceptor(err)
+
+		st, ok := status.FromError(got)
+		if !ok {
+			t.Fatalf("Expected a gRPC status error, got %v", got)
+		}
+		if st.Code() != codes.NotFound {
+			t.Errorf("Expected code NotFound, got %v", st.Code())
+		}
+		if st.Message() != "Policy not found" {
+			t.Errorf("Expected message 'Policy not found', got %q", st.Message())
+		}
+		if code, _, ok := StatusDetail(st); !ok || code != 20001 {
+			t.Errorf("Expected detail code 20001, got %d (ok=%v)", code, ok)
+		}
+	}
+}
+
+func TestUnaryServerInterceptor_PassesThrough(t *testing.T) {
+	plain := errors.New("boom")
+	resp, err := invokeInterceptor(plain)
+	if err != plain {
+		t.Errorf("Expected non-RC error to pass through, got %v", err)
+	}
+	if resp != "resp" {
+		t.Errorf("Expected response to pass through, got %v", resp)
+	}
+
+	if _, err := invokeInterceptor(nil); err != nil {
+		t.Errorf("Expected nil error, got %v", err)
+	}
+}
+
+func TestUnaryServerInterceptor_HidesCause(t *testing.T) {
+	rc := New(90001, 500, codes.Internal, "Internal error")(errors.New("dial tcp db.internal:5432 password=hunter2"))
+
+	_, got := invokeInterceptor(rc)
+
+	st, ok := status.FromError(got)
+	if !ok {
+		t.Fatalf("Expected a gRPC status error, got %v", got)
+	}
+	if st.Message() != "Internal error" {
+		t.Errorf("Expected message 'Internal error', got %q", st.Message())
+	}
+}
+
+func TestUnaryServerInterceptor_OK(t *testing.T) {
+	rc := New(20002, 200, codes.OK, "Accepted")()
+
+	_, got := invokeInterceptor(rc)
+
+	if got == nil {
+		t.Fatal("Expected an error for an OK-coded RC, got nil")
+	}
+	st, ok := status.FromError(got)
+	if !ok {
+		t.Fatalf("Expected a gRPC status error, got %v", got)
+	}
+	if st.Code() != codes.Unknown {
+		t.Errorf("Expected code Unknown, got %v", st.Code())
+	}
+	if st.Message() != "Accepted" {
+		t.Errorf("Expected message 'Accepted', got %q", st.Message())
+	}
+}
@@ -0,0 +1,201 @@
+package generator
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// checkDataExamples validates every data_example against its data_schema.
+func checkDataExamples(errors []ErrorDefinition) error {
+	for _, errDef := range errors {
+		if errDef.DataSchema == nil || errDef.DataExample == nil {
+			continue
+		}
+		if err := validateAgainstSchema(errDef.DataExample, errDef.DataSchema, "data_example"); err != nil {
+			return fmt.Errorf("key %s: %w", errDef.Key, err)
+		}
+	}
+	return nil
+}
+
+// validateAgainstSchema checks value against a JSON Schema. It supports the
+// subset useful for example payloads: type, enum, required, properties,
+// additionalProperties (as a boolean) and items.
+func validateAgainstSchema(value any, schema map[string]any, path string) error {
+	if typ, ok := schema["type"].(string); ok && !schemaTypeMatches(value, typ) {
+		return fmt.Errorf("%s: expected %s, got %s", path, typ, describeValue(value))
+	}
+
+	if enum, ok := schema["enum"].([]any); ok {
+		found := false
+		for _, allowed := range enum {
+			if valuesEqual(value, allowed) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%s: %v is not one of %v", path, value, enum)
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		properties, _ := schema["properties"].(map[string]any)
+		if required, ok := schema["required"].([]any); ok {
+			for _, name := range required {
+				if _, set := v[fmt.Sprint(name)]; !set {
+					return fmt.Errorf("%s: missing required property %q", path, name)
+				}
+			}
+		}
+		for _, name := range sortedKeys(v) {
+			propSchema, known := properties[name].(map[string]any)
+			if !known {
+				if additional, ok := schema["additionalProperties"].(bool); ok && !additional {
+					return fmt.Errorf("%s: unexpected property %q", path, name)
+				}
+				continue
+			}
+			if err := validateAgainstSchema(v[name], propSchema, path+"."+name); err != nil {
+				return err
+			}
+		}
+	case []any:
+		if items, ok := schema["items"].(map[string]any); ok {
+			for i, item := range v {
+				if err := validateAgainstSchema(item, items, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// schemaTypeMatches reports whether value has the JSON Schema type typ.
+func schemaTypeMatches(value any, typ string) bool {
+	switch typ {
+	case "object":
+		_, ok := value.(map[string]any)
+		return ok
+	case "array":
+		_, ok := value.([]any)
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "null":
+		return value == nil
+	case "number":
+		_, ok := toFloat(value)
+		return ok
+	case "integer":
+		f, ok := toFloat(value)
+		return ok && f == math.Trunc(f)
+	}
+	return true
+}
+
+// describeValue names the JSON type of value for error messages.
+func describeValue(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	}
+	if _, ok := toFloat(value); ok {
+		return "number"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// toFloat converts the numeric types produced by the YAML and JSON decoders.
+func toFloat(value any) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
+// valuesEqual compares decoded values, treating numbers of different Go types
+// as equal when their values are.
+func valuesEqual(a, b any) bool {
+	if fa, ok := toFloat(a); ok {
+		fb, ok := toFloat(b)
+		return ok && fa == fb
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// goLiteral renders a decoded YAML or JSON value as a Go expression. Objects
+// become map[string]any with sorted keys and arrays become []any.
+func goLiteral(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "nil", nil
+	case string:
+		return strconv.Quote(v), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case uint64:
+		return strconv.FormatUint(v, 10), nil
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) < 1<<53 {
+			return strconv.FormatInt(int64(v), 10), nil
+		}
+		return strconv.FormatFloat(v, 'g', -1, 64), nil
+	case []any:
+		items := make([]string, len(v))
+		for i, item := range v {
+			lit, err := goLiteral(item)
+			if err != nil {
+				return "", err
+			}
+			items[i] = lit
+		}
+		return "[]any{" + strings.Join(items, ", ") + "}", nil
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		entries := make([]string, len(keys))
+		for i, key := range keys {
+			lit, err := goLiteral(v[key])
+			if err != nil {
+				return "", err
+			}
+			entries[i] = strconv.Quote(key) + ": " + lit
+		}
+		return "map[string]any{" + strings.Join(entries, ", ") + "}", nil
+	}
+	return "", fmt.Errorf("unsupported data_example value of type %T", value)
+}
@@ -0,0 +1,82 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerate_DataExample(t *testing.T) {
+	yamlContent := `- code: 20001
+  key: InvalidKind
+  message: Invalid policy kind
+  http: 400
+  grpc: 3
+  data_schema:
+    type: object
+    required: [field, allowed]
+    additionalProperties: false
+    properties:
+      field: {type: string}
+      allowed: {type: array, items: {type: string}}
+      retries: {type: integer}
+  data_example:
+    field: kind
+    allowed: [allow, deny]
+    retries: 2`
+
+	errors, err := ParseInput(strings.NewReader(yamlContent), "test.yaml")
+	if err != nil {
+		t.Fatalf("Failed to parse input: %v", err)
+	}
+
+	code, err := Generate(Config{Package: "testpkg", Errors: errors})
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	expected := `rescode.New(InvalidKindCode, InvalidKindHTTP, InvalidKindGRPC, InvalidKindMsg, map[string]any{"allowed": []any{"allow", "deny"}, "field": "kind", "retries": 2})(err...)`
+	if !strings.Contains(string(code), expected) {
+		t.Errorf("Generated code should contain %q", expected)
+	}
+}
+
+func TestGenerate_DataExampleMismatch(t *testing.T) {
+	schema := map[string]any{
+		"type":                 "object",
+		"required":             []any{"field"},
+		"additionalProperties": false,
+		"properties": map[string]any{
+			"field": map[string]any{"type": "string"},
+			"kind":  map[string]any{"enum": []any{"allow", "deny"}},
+			"count": map[string]any{"type": "integer"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		example  any
+		expected string
+	}{
+		{"wrong type", "kind", "data_example: expected object, got string"},
+		{"missing required", map[string]any{"kind": "allow"}, `missing required property "field"`},
+		{"wrong property type", map[string]any{"field": 1}, "data_example.field: expected string, got number"},
+		{"not in enum", map[string]any{"field": "a", "kind": "maybe"}, "data_example.kind: maybe is not one of"},
+		{"not an integer", map[string]any{"field": "a", "count": 1.5}, "data_example.count: expected integer"},
+		{"additional property", map[string]any{"field": "a", "extra": true}, `unexpected property "extra"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := Config{
+				Package: "testpkg",
+				Errors: []ErrorDefinition{
+					{Code: 20001, Key: "InvalidKind", Message: "Invalid policy kind", HTTP: 400, GRPC: 3, DataSchema: schema, DataExample: tt.example},
+				},
+			}
+			_, err := Generate(config)
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("Expected error containing %q, got %v", tt.expected, err)
+			}
+		})
+	}
+}
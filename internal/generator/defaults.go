@@ -0,0 +1,138 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// expandJSONDefaults rewrites the object form of a JSON input file,
+//
+//	{
+//	  "defaults": {"http": 400, "grpc": 3},
+//	  "errors": [{"code": 20001, ...}]
+//	}
+//
+// into the bare-list form by copying each defaults field into every entry
+// that does not set it. Input already in the bare-list form, or that is not
+// valid JSON, is returned unchanged.
+func expandJSONDefaults(data []byte) ([]byte, error) {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) == 0 || trimmed[0] != '{' {
+		return data, nil
+	}
+
+	var file map[string]json.RawMessage
+	if err := json.Unmarshal(data, &file); err != nil {
+		// Leave the syntax error to be reported by the main decoder
+		return data, nil
+	}
+	if err := checkTopLevelFields(sortedKeys(file)); err != nil {
+		return nil, err
+	}
+
+	var defaults map[string]json.RawMessage
+	if raw, ok := file["defaults"]; ok {
+		if err := json.Unmarshal(raw, &defaults); err != nil {
+			return nil, fmt.Errorf("defaults must be an object: %w", err)
+		}
+	}
+	var entries []map[string]json.RawMessage
+	if err := json.Unmarshal(file["errors"], &entries); err != nil {
+		return nil, fmt.Errorf("errors must be a list of error definitions: %w", err)
+	}
+
+	for _, entry := range entries {
+		for name, value := range defaults {
+			if _, set := entry[name]; !set {
+				entry[name] = value
+			}
+		}
+	}
+	return json.Marshal(entries)
+}
+
+// expandYAMLDefaults parses YAML input and returns the node holding its list
+// of definitions. In the object form,
+//
+//	defaults:
+//	  http: 400
+//	  grpc: 3
+//	errors:
+//	  - code: 20001
+//	    ...
+//
+// each defaults field is copied into every entry that does not set it. The
+// nodes keep their positions, so decoding errors point at the input's lines.
+func expandYAMLDefaults(data []byte) (*yaml.Node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		return &yaml.Node{Kind: yaml.SequenceNode}, nil
+	}
+	// The bare-list form, or anything else, is left to the decoder
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return root, nil
+	}
+
+	var names []string
+	var defaults, errorList *yaml.Node
+	for i := 0; i < len(root.Content); i += 2 {
+		name := root.Content[i].Value
+		names = append(names, name)
+		switch name {
+		case "defaults":
+			defaults = root.Content[i+1]
+		case "errors":
+			errorList = root.Content[i+1]
+		}
+	}
+	if err := checkTopLevelFields(names); err != nil {
+		return nil, err
+	}
+	if errorList == nil || errorList.Kind != yaml.SequenceNode {
+		return nil, fmt.Errorf("errors must be a list of error definitions")
+	}
+	if defaults != nil && defaults.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("defaults must be a mapping")
+	}
+
+	for _, entry := range errorList.Content {
+		if defaults == nil || entry.Kind != yaml.MappingNode {
+			continue
+		}
+		set := make(map[string]bool)
+		for i := 0; i < len(entry.Content); i += 2 {
+			set[entry.Content[i].Value] = true
+		}
+		for i := 0; i < len(defaults.Content); i += 2 {
+			if !set[defaults.Content[i].Value] {
+				entry.Content = append(entry.Content, defaults.Content[i], defaults.Content[i+1])
+			}
+		}
+	}
+	return errorList, nil
+}
+
+// checkTopLevelFields rejects keys other than defaults and errors in the
+// object form, and requires errors to be present.
+func checkTopLevelFields(names []string) error {
+	hasErrors := false
+	for _, name := range names {
+		switch name {
+		case "defaults":
+		case "errors":
+			hasErrors = true
+		default:
+			return fmt.Errorf("unknown top-level field %q: expected defaults or errors", name)
+		}
+	}
+	if !hasErrors {
+		return fmt.Errorf("missing top-level errors list")
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseInput_Defaults(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		input    string
+	}{
+		{
+			name:     "YAML",
+			filename: "test.yaml",
+			input: `defaults:
+  http: 400
+  grpc: 3
+errors:
+  - code: 20001
+    key: InvalidKind
+    message: Invalid policy kind
+  - code: 20002
+    key: PolicyNotFound
+    message: Policy not found
+    http: 404
+    grpc: 5`,
+		},
+		{
+			name:     "JSON",
+			filename: "test.json",
+			input: `{
+  "defaults": {"http": 400, "grpc": 3},
+  "errors": [
+    {"code": 20001, "key": "InvalidKind", "message": "Invalid policy kind"},
+    {"code": 20002, "key": "PolicyNotFound", "message": "Policy not found", "http": 404, "grpc": 5}
+  ]
+}`,
+		},
+		{
+			name:     "auto-detected",
+			filename: "",
+			input: `defaults: {http: 400, grpc: 3}
+errors:
+  - {code: 20001, key: InvalidKind, message: Invalid policy kind}
+  - {code: 20002, key: PolicyNotFound, message: Policy not found, http: 404, grpc: 5}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errors, err := ParseInput(strings.NewReader(tt.input), tt.filename)
+			if err != nil {
+				t.Fatalf("Failed to parse input: %v", err)
+			}
+			if len(errors) != 2 {
+				t.Fatalf("Expected 2 errors, got %d", len(errors))
+			}
+			if errors[0].HTTP != 400 || errors[0].GRPC != 3 {
+				t.Errorf("Expected defaults to fill in http/grpc, got %d/%d", errors[0].HTTP, errors[0].GRPC)
+			}
+			if errors[1].HTTP != 404 || errors[1].GRPC != 5 {
+				t.Errorf("Expected entry to override defaults, got %d/%d", errors[1].HTTP, errors[1].GRPC)
+			}
+		})
+	}
+}
+
+func TestParseInput_DefaultsInferGRPC(t *testing.T) {
+	input := `defaults:
+  http: 404
+errors:
+  - code: 20001
+    key: PolicyNotFound
+    message: Policy not found`
+
+	errors, err := ParseInput(strings.NewReader(input), "test.yaml")
+	if err != nil {
+		t.Fatalf("Failed to parse input: %v", err)
+	}
+	if errors[0].GRPC != 5 {
+		t.Errorf("Expected gRPC to be inferred from the default http, got %d", errors[0].GRPC)
+	}
+}
+
+func TestParseInput_DefaultsErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"unknown top-level field", "defaults: {http: 400}\nerrorz: []", `unknown top-level field "errorz"`},
+		{"missing errors", "defaults: {http: 400}", "missing top-level errors list"},
+		{"unknown default field", "defaults: {htpt: 400}\nerrors:\n  - {code: 1, key: A, message: A, http: 400}", `unknown field "htpt"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseInput(strings.NewReader(tt.input), "test.yaml")
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("Expected error containing %q, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestParseInput_DefaultsLineNumbers(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name: "unknown field",
+			input: `defaults:
+  http: 400
+errors:
+  - code: 20001
+    key: InvalidKind
+    message: Invalid policy kind
+    htpt: 404`,
+			expected: `unknown field "htpt" (line 7)`,
+		},
+		{
+			name: "type error",
+			input: `defaults:
+  http: 400
+errors:
+  - key: InvalidKind
+    message: Invalid policy kind
+    code: twenty`,
+			expected: "line 6",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseInput(strings.NewReader(tt.input), "test.yaml")
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("Expected error containing %q, got %v", tt.expected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Deprecation marks an error definition as deprecated. In input files it is
+// written either as a boolean (deprecated: true) or as a reason string
+// (deprecated: "use PolicyMissing instead").
+type Deprecation struct {
+	Deprecated bool
+	Reason     string
+}
+
+// UnmarshalYAML accepts a boolean or a reason string.
+func (d *Deprecation) UnmarshalYAML(node *yaml.Node) error {
+	var flag bool
+	if err := node.Decode(&flag); err == nil {
+		*d = Deprecation{Deprecated: flag}
+		return nil
+	}
+
+	var reason string
+	if err := node.Decode(&reason); err != nil {
+		return fmt.Errorf("deprecated must be a boolean or a string")
+	}
+	*d = Deprecation{Deprecated: true, Reason: reason}
+	return nil
+}
+
+// UnmarshalJSON accepts a boolean or a reason string.
+func (d *Deprecation) UnmarshalJSON(data []byte) error {
+	var flag bool
+	if err := json.Unmarshal(data, &flag); err == nil {
+		*d = Deprecation{Deprecated: flag}
+		return nil
+	}
+
+	var reason string
+	if err := json.Unmarshal(data, &reason); err != nil {
+		return fmt.Errorf("deprecated must be a boolean or a string")
+	}
+	*d = Deprecation{Deprecated: true, Reason: reason}
+	return nil
+}
@@ -0,0 +1,180 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// GenerateRangesDoc creates a markdown table describing the code range,
+// definition count and deprecated codes of each category. Definitions without
+// a category are listed last under "(none)".
+func GenerateRangesDoc(errors []ErrorDefinition) []byte {
+	uncategorized, categories, grouped := GroupByCategory(errors)
+	if len(uncategorized) > 0 {
+		categories = append(categories, "")
+		grouped[""] = uncategorized
+	}
+
+	var builder strings.Builder
+	builder.WriteString("# Error Code Ranges\n\n")
+	builder.WriteString("| Category | Range | Count | Deprecated |\n")
+	builder.WriteString("|----------|-------|-------|------------|\n")
+
+	for _, category := range categories {
+		defs := grouped[category]
+		min, max := defs[0].Code, defs[0].Code
+		var deprecated []string
+		for _, errDef := range defs {
+			if errDef.Code < min {
+				min = errDef.Code
+			}
+			if errDef.Code > max {
+				max = errDef.Code
+			}
+			if errDef.Deprecated.Deprecated {
+				deprecated = append(deprecated, strconv.FormatUint(errDef.Code, 10))
+			}
+		}
+
+		name := category
+		if name == "" {
+			name = "(none)"
+		}
+		deprecatedCol := strings.Join(deprecated, ", ")
+		if deprecatedCol == "" {
+			deprecatedCol = "-"
+		}
+		builder.WriteString(fmt.Sprintf("| %s | %d-%d | %d | %s |\n", name, min, max, len(defs), deprecatedCol))
+	}
+
+	return []byte(builder.String())
+}
+
+// GenerateCatalogDoc creates a markdown table listing every definition. Meta
+// entries are included as extra columns, one per meta key in sorted order,
+// so governance fields such as owner or ticket appear without affecting the
+// generated Go code.
+func GenerateCatalogDoc(errors []ErrorDefinition) []byte {
+	var metaKeys []string
+	seen := make(map[string]bool)
+	for _, errDef := range errors {
+		for key := range errDef.Meta {
+			if !seen[key] {
+				seen[key] = true
+				metaKeys = append(metaKeys, key)
+			}
+		}
+	}
+	sort.Strings(metaKeys)
+
+	var builder strings.Builder
+	builder.WriteString("# Error Catalog\n\n")
+	builder.WriteString("| Code | Key | Message | HTTP | gRPC |")
+	for _, key := range metaKeys {
+		builder.WriteString(" " + markdownCell(key) + " |")
+	}
+	builder.WriteString("\n|------|-----|---------|------|------|")
+	for _, key := range metaKeys {
+		builder.WriteString(strings.Repeat("-", len(key)+2) + "|")
+	}
+	builder.WriteString("\n")
+
+	for _, errDef := range errors {
+		builder.WriteString(fmt.Sprintf("| %d | %s | %s | %d | %d |", errDef.Code, errDef.Key, markdownCell(errDef.Message), errDef.HTTP, errDef.GRPC))
+		for _, key := range metaKeys {
+			value := errDef.Meta[key]
+			if value == "" {
+				value = "-"
+			}
+			builder.WriteString(" " + markdownCell(value) + " |")
+		}
+		builder.WriteString("\n")
+	}
+
+	return []byte(builder.String())
+}
+
+// markdownCell escapes text for use in a markdown table cell.
+func markdownCell(text string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(text, "|", "\\|"), "\n", " ")
+}
+
+// severityColors maps each severity to the fill color of its DOT nodes.
+var severityColors = map[string]string{
+	"critical": "red",
+	"high":     "orange",
+	"medium":   "yellow",
+	"low":      "lightblue",
+	"info":     "lightgray",
+}
+
+// severity returns the severity of errDef: its meta "severity" entry when
+// set, otherwise "high" for 5xx, "medium" for 4xx and "low" for any other
+// HTTP status.
+func severity(errDef ErrorDefinition) string {
+	if s := errDef.Meta["severity"]; s != "" {
+		return strings.ToLower(s)
+	}
+	switch {
+	case errDef.HTTP >= 500:
+		return "high"
+	case errDef.HTTP >= 400:
+		return "medium"
+	default:
+		return "low"
+	}
+}
+
+// GenerateDOT creates a Graphviz graph of the catalog with one cluster
+// subgraph per category and one node per error, filled by severity. Nodes of
+// uncategorized errors sit outside any cluster, and replaced_by is drawn as a
+// dashed edge from the deprecated error to its replacement.
+func GenerateDOT(errors []ErrorDefinition) []byte {
+	uncategorized, categories, grouped := GroupByCategory(errors)
+
+	var builder strings.Builder
+	builder.WriteString("digraph errors {\n")
+	builder.WriteString("\trankdir=LR;\n")
+	builder.WriteString("\tnode [shape=box, style=filled];\n")
+
+	for _, category := range categories {
+		builder.WriteString(fmt.Sprintf("\n\tsubgraph %q {\n", "cluster_"+category))
+		builder.WriteString(fmt.Sprintf("\t\tlabel=%q;\n", category))
+		for _, errDef := range grouped[category] {
+			builder.WriteString("\t\t" + dotNode(errDef))
+		}
+		builder.WriteString("\t}\n")
+	}
+
+	if len(uncategorized) > 0 {
+		builder.WriteString("\n")
+		for _, errDef := range uncategorized {
+			builder.WriteString("\t" + dotNode(errDef))
+		}
+	}
+
+	var edges []string
+	for _, errDef := range errors {
+		if errDef.ReplacedBy != "" {
+			edges = append(edges, fmt.Sprintf("\t%q -> %q [style=dashed, label=\"replaced by\"];\n", errDef.Key, errDef.ReplacedBy))
+		}
+	}
+	if len(edges) > 0 {
+		builder.WriteString("\n" + strings.Join(edges, ""))
+	}
+
+	builder.WriteString("}\n")
+	return []byte(builder.String())
+}
+
+// dotNode returns the DOT statement declaring the node for errDef.
+func dotNode(errDef ErrorDefinition) string {
+	color, ok := severityColors[severity(errDef)]
+	if !ok {
+		color = "white"
+	}
+	label := fmt.Sprintf("%d %s", errDef.Code, errDef.Key)
+	return fmt.Sprintf("%q [label=%q, fillcolor=%q];\n", errDef.Key, label, color)
+}
@@ -0,0 +1,161 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateRangesDoc(t *testing.T) {
+	yamlInput := `
+- code: 10001
+  key: LoginFailed
+  message: Login failed
+  http: 401
+  grpc: 16
+  category: auth
+- code: 10005
+  key: TokenExpired
+  message: Token expired
+  http: 401
+  grpc: 16
+  category: auth
+  deprecated: use LoginFailed instead
+- code: 20001
+  key: PolicyNotFound
+  message: Policy not found
+  http: 404
+  grpc: 5
+  category: policy
+- code: 90001
+  key: Unknown
+  message: Unknown error
+  http: 500
+  grpc: 2
+`
+
+	errors, err := ParseInput(strings.NewReader(yamlInput), "test.yaml")
+	if err != nil {
+		t.Fatalf("Failed to parse YAML: %v", err)
+	}
+
+	doc := string(GenerateRangesDoc(errors))
+
+	expected := []string{
+		"| Category | Range | Count | Deprecated |",
+		"| auth | 10001-10005 | 2 | 10005 |",
+		"| policy | 20001-20001 | 1 | - |",
+		"| (none) | 90001-90001 | 1 | - |",
+	}
+	for _, exp := range expected {
+		if !strings.Contains(doc, exp) {
+			t.Errorf("Ranges doc should contain %q, got:\n%s", exp, doc)
+		}
+	}
+}
+
+func TestDeprecation_Parse(t *testing.T) {
+	jsonInput := `[
+  {"code": 1, "key": "A", "message": "A", "http": 400, "grpc": 3, "deprecated": true},
+  {"code": 2, "key": "B", "message": "B", "http": 400, "grpc": 3, "deprecated": "use A instead"},
+  {"code": 3, "key": "C", "message": "C", "http": 400, "grpc": 3}
+]`
+
+	errors, err := ParseInput(strings.NewReader(jsonInput), "test.json")
+	if err != nil {
+		t.Fatalf("Failed to parse JSON: %v", err)
+	}
+
+	expected := []Deprecation{
+		{Deprecated: true},
+		{Deprecated: true, Reason: "use A instead"},
+		{},
+	}
+	for i, want := range expected {
+		if errors[i].Deprecated != want {
+			t.Errorf("Expected definition %d deprecation %+v, got %+v", i, want, errors[i].Deprecated)
+		}
+	}
+
+	if _, err := ParseInput(strings.NewReader("- code: 1\n  key: A\n  message: A\n  http: 400\n  grpc: 3\n  deprecated: [x]\n"), "test.yaml"); err == nil {
+		t.Error("Expected error for non-boolean, non-string deprecated value")
+	}
+}
+
+func TestGenerateCatalogDoc_Meta(t *testing.T) {
+	yamlInput := `
+- code: 10001
+  key: LoginFailed
+  message: Login failed
+  http: 401
+  grpc: 16
+  meta:
+    owner: team-auth
+    ticket: AUTH-12
+- code: 20001
+  key: PolicyNotFound
+  message: Policy not found
+  http: 404
+  grpc: 5
+  meta:
+    owner: team-policy
+    since: v1.4
+`
+
+	errors, err := ParseInput(strings.NewReader(yamlInput), "test.yaml")
+	if err != nil {
+		t.Fatalf("Failed to parse YAML: %v", err)
+	}
+	if errors[0].Meta["owner"] != "team-auth" || errors[1].Meta["since"] != "v1.4" {
+		t.Errorf("Expected meta to be parsed, got %v and %v", errors[0].Meta, errors[1].Meta)
+	}
+
+	doc := string(GenerateCatalogDoc(errors))
+	expected := []string{
+		"| Code | Key | Message | HTTP | gRPC | owner | since | ticket |",
+		"| 10001 | LoginFailed | Login failed | 401 | 16 | team-auth | - | AUTH-12 |",
+		"| 20001 | PolicyNotFound | Policy not found | 404 | 5 | team-policy | v1.4 | - |",
+	}
+	for _, exp := range expected {
+		if !strings.Contains(doc, exp) {
+			t.Errorf("Catalog doc should contain %q, got:\n%s", exp, doc)
+		}
+	}
+
+	code, err := Generate(Config{Package: "testpkg", Errors: errors})
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+	if strings.Contains(string(code), "team-auth") {
+		t.Error("Meta should not affect the generated Go code")
+	}
+}
+
+func TestGenerateDOT(t *testing.T) {
+	errors := []ErrorDefinition{
+		{Code: 10001, Key: "LoginFailed", Message: "Login failed", HTTP: 401, GRPC: 16, Category: "auth"},
+		{Code: 10005, Key: "TokenExpired", Message: "Token expired", HTTP: 401, GRPC: 16, Category: "auth", ReplacedBy: "LoginFailed"},
+		{Code: 20001, Key: "PolicyNotFound", Message: "Policy not found", HTTP: 404, GRPC: 5, Category: "policy", Meta: map[string]string{"severity": "Critical"}},
+		{Code: 90001, Key: "Unknown", Message: "Unknown error", HTTP: 500, GRPC: 2},
+	}
+
+	dot := string(GenerateDOT(errors))
+
+	expected := []string{
+		"digraph errors {",
+		"subgraph \"cluster_auth\" {\n\t\tlabel=\"auth\";",
+		"subgraph \"cluster_policy\" {\n\t\tlabel=\"policy\";",
+		"\"LoginFailed\" [label=\"10001 LoginFailed\", fillcolor=\"yellow\"];",
+		"\"TokenExpired\" [label=\"10005 TokenExpired\", fillcolor=\"yellow\"];",
+		"\"PolicyNotFound\" [label=\"20001 PolicyNotFound\", fillcolor=\"red\"];",
+		"\t\"Unknown\" [label=\"90001 Unknown\", fillcolor=\"orange\"];",
+		"\"TokenExpired\" -> \"LoginFailed\" [style=dashed, label=\"replaced by\"];",
+	}
+	for _, exp := range expected {
+		if !strings.Contains(dot, exp) {
+			t.Errorf("DOT output should contain %q, got:\n%s", exp, dot)
+		}
+	}
+	if strings.Count(dot, "subgraph") != 2 {
+		t.Errorf("Expected one subgraph per category, got:\n%s", dot)
+	}
+}
@@ -4,78 +4,582 @@ import (
 	"encoding/json"
 	"fmt"
 	"go/format"
+	"go/token"
 	"io"
+	"os"
+	"path"
 	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"gopkg.in/yaml.v3"
 )
 
 // ErrorDefinition represents a single error definition from the input file.
 type ErrorDefinition struct {
-	Code    uint64 `json:"code" yaml:"code"`
-	Key     string `json:"key" yaml:"key"`
-	Message string `json:"message" yaml:"message"`
-	HTTP    int    `json:"http" yaml:"http"`
-	GRPC    int    `json:"grpc" yaml:"grpc"`
-	Desc    string `json:"desc" yaml:"desc"`
+	Code        uint64            `json:"code" yaml:"code"`
+	Key         string            `json:"key" yaml:"key"`
+	Message     string            `json:"message" yaml:"message"`
+	HTTP        int               `json:"http" yaml:"http"`
+	GRPC        int               `json:"grpc" yaml:"grpc"`
+	Desc        string            `json:"desc" yaml:"desc"`
+	Category    string            `json:"category" yaml:"category"`
+	Group       string            `json:"group" yaml:"group"`
+	Deprecated  Deprecation       `json:"deprecated" yaml:"deprecated"`
+	Fields      []string          `json:"fields" yaml:"fields"`
+	Suggestion  string            `json:"suggestion" yaml:"suggestion"`
+	UUID        string            `json:"uuid" yaml:"uuid"`
+	ReplacedBy  string            `json:"replaced_by" yaml:"replaced_by"`
+	Meta        map[string]string `json:"meta" yaml:"meta"`
+	DataSchema  map[string]any    `json:"data_schema" yaml:"data_schema"`
+	DataExample any               `json:"data_example" yaml:"data_example"`
 }
 
+// DefaultImportPath is the import path of the rescode package used by
+// generated code unless Config.ImportPath is set.
+const DefaultImportPath = "github.com/restayway/rescode"
+
 // Config holds the configuration for code generation.
 type Config struct {
 	Package string
 	Errors  []ErrorDefinition
+
+	// Sentinels emits an ErrXxx variable per error for use with errors.Is.
+	Sentinels bool
+	// CodeEnum emits a typed Code enumeration with AllCodes and String.
+	CodeEnum bool
+	// Must emits a MustXxx factory per error that requires a non-nil cause.
+	Must bool
+	// Keys emits a sorted Keys slice listing every error key.
+	Keys bool
+	// SSE emits a CatalogSSE handler streaming the catalog as Server-Sent Events.
+	SSE bool
+	// CountGuard emits an ErrorCount constant and an array literal sized to it
+	// listing every factory, so they cannot drift apart without a compile error.
+	CountGuard bool
+	// Responses emits a XxxResponse() constructor per error returning its
+	// rescode.Response body.
+	Responses bool
+	// KeyForCode emits a KeyForCode(code) function returning the key for a
+	// numeric code, for labelling codes found in logs.
+	KeyForCode bool
+	// Decoder emits a DecodeError(body) function rebuilding an RC from a JSON
+	// error response, restoring catalog metadata for known codes.
+	Decoder bool
+	// CLI emits an ErrorsCommand function implementing an "errors list"
+	// subcommand that prints the catalog as a table.
+	CLI bool
+	// Validation emits a ValidationError(field) function returning the error
+	// whose fields list the failed request field.
+	Validation bool
+	// UUIDs emits an XxxUUID constant for every error, deriving a deterministic
+	// UUIDv5 from the key when the definition has no uuid. Definitions with a
+	// uuid always emit it.
+	UUIDs bool
+	// TypedConstants emits the XxxHTTP constants as a named HTTPStatus type
+	// instead of a plain int, so they cannot be mixed up with unrelated ints.
+	TypedConstants bool
+	// ImportPath is the import path of the rescode package used by generated
+	// code, for forks and vendored copies. It defaults to DefaultImportPath.
+	ImportPath string
+	// CodeType is the unsigned integer type of the emitted code constants and
+	// Code enumeration: uint8, uint16, uint32 or uint64 (the default).
+	CodeType string
+	// Header is prepended to generated files above the generated-code marker,
+	// for example a license notice. Lines not already comments are commented.
+	Header string
+	// Template, when non-empty, is a text/template source executed with the
+	// Config in place of the built-in layout. The Header and generated-code
+	// marker are still prepended; the other options are ignored.
+	Template string
+
+	// shard is set by GenerateSplit to generate one file of a split package.
+	shard *shard
 }
 
-// ParseInput reads and parses the input file (YAML or JSON) into error definitions.
+// shard describes one file of a package split across several files.
+type shard struct {
+	// declared lists the definitions whose constants and factories the file
+	// declares. Config.Errors remains the whole catalog.
+	declared []ErrorDefinition
+	// catalog makes the file hold the package-wide declarations, such as the
+	// Keys slice or the HTTPStatus type.
+	catalog bool
+}
+
+// ParseInput reads and parses the input file (YAML, JSON or .proto) into error definitions.
 func ParseInput(reader io.Reader, filename string) ([]ErrorDefinition, error) {
 	data, err := io.ReadAll(reader)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read input: %w", err)
 	}
 
+	return ParseInputBytes(data, filename)
+}
+
+// ParseInputFile opens and parses the file at path, detecting the format from
+// its extension.
+func ParseInputFile(path string) ([]ErrorDefinition, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read input: %w", err)
+	}
+
+	return ParseInputBytes(data, path)
+}
+
+// ParseInputBytes parses raw input into error definitions. The formatHint may
+// be a filename ("errors.yaml"), an extension (".json") or a bare format name
+// ("yaml"); when empty or unrecognized the format is auto-detected. JSON input
+// (.json, .jsonc or .json5) may contain // and /* */ comments and trailing
+// commas.
+func ParseInputBytes(data []byte, formatHint string) ([]ErrorDefinition, error) {
 	var errors []ErrorDefinition
 
 	// Determine format by file extension
-	ext := strings.ToLower(filepath.Ext(filename))
+	ext := strings.ToLower(filepath.Ext(formatHint))
+	if ext == "" && formatHint != "" {
+		ext = "." + strings.ToLower(formatHint)
+	}
+
+	// JSON input may carry comments and trailing commas
+	if ext == ".jsonc" || ext == ".json5" {
+		ext = ".json"
+	}
+	if ext == ".json" {
+		data = stripJSONComments(data)
+	}
+
+	// A top-level defaults block is merged into each entry: for JSON by
+	// rewriting the input, for YAML on the parsed nodes so errors keep the
+	// line numbers of the original input
+	var unknown error
 	switch ext {
 	case ".yaml", ".yml":
-		if err := yaml.Unmarshal(data, &errors); err != nil {
+		list, err := expandYAMLDefaults(data)
+		if err != nil {
+			return nil, err
+		}
+		if err := list.Decode(&errors); err != nil {
 			return nil, fmt.Errorf("failed to parse YAML: %w", err)
 		}
+		unknown = checkUnknownYAMLFields(list)
 	case ".json":
+		data, err := expandJSONDefaults(data)
+		if err != nil {
+			return nil, err
+		}
 		if err := json.Unmarshal(data, &errors); err != nil {
 			return nil, fmt.Errorf("failed to parse JSON: %w", err)
 		}
+		unknown = checkUnknownJSONFields(data)
+	case ".proto":
+		var err error
+		if errors, err = parseProtoBytes(data); err != nil {
+			return nil, err
+		}
 	default:
 		// Try to auto-detect by attempting JSON first, then YAML
-		if err := json.Unmarshal(data, &errors); err != nil {
-			if yamlErr := yaml.Unmarshal(data, &errors); yamlErr != nil {
+		jsonData, err := expandJSONDefaults(data)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(jsonData, &errors); err != nil {
+			list, yamlErr := expandYAMLDefaults(data)
+			if yamlErr == nil {
+				yamlErr = list.Decode(&errors)
+			}
+			if yamlErr != nil {
 				return nil, fmt.Errorf("failed to parse as JSON or YAML - JSON error: %v, YAML error: %v", err, yamlErr)
 			}
+			unknown = checkUnknownYAMLFields(list)
+		} else {
+			unknown = checkUnknownJSONFields(jsonData)
+		}
+	}
+	if unknown != nil {
+		return nil, unknown
+	}
+
+	if err := inferGRPC(errors); err != nil {
+		return nil, err
+	}
+
+	if err := validate(errors); err != nil {
+		return nil, err
+	}
+
+	return errors, nil
+}
+
+// UnmarshalYAML decodes a definition, marking an omitted grpc field so it can
+// be inferred from the HTTP status.
+func (d *ErrorDefinition) UnmarshalYAML(node *yaml.Node) error {
+	type plain ErrorDefinition
+	if err := node.Decode((*plain)(d)); err != nil {
+		return err
+	}
+
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i < len(node.Content); i += 2 {
+			if node.Content[i].Value == "grpc" {
+				return nil
+			}
+		}
+		d.GRPC = grpcUnset
+	}
+	return nil
+}
+
+// UnmarshalJSON decodes a definition, marking an omitted grpc field so it can
+// be inferred from the HTTP status.
+func (d *ErrorDefinition) UnmarshalJSON(data []byte) error {
+	type plain ErrorDefinition
+	if err := json.Unmarshal(data, (*plain)(d)); err != nil {
+		return err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	// encoding/json matches field names case-insensitively
+	for name := range fields {
+		if strings.EqualFold(name, "grpc") {
+			return nil
+		}
+	}
+	d.GRPC = grpcUnset
+	return nil
+}
+
+// knownFields returns the field names accepted in input files, taken from the
+// JSON tags of ErrorDefinition (which match the YAML tags).
+func knownFields() map[string]bool {
+	fields := make(map[string]bool)
+	t := reflect.TypeOf(ErrorDefinition{})
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			fields[name] = true
+		}
+	}
+	return fields
+}
+
+// checkUnknownYAMLFields rejects entries with keys that are not definition
+// fields, so a typo such as "htpt" is reported instead of silently ignored.
+func checkUnknownYAMLFields(list *yaml.Node) error {
+	var entries []map[string]yaml.Node
+	if err := list.Decode(&entries); err != nil {
+		return nil
+	}
+
+	known := knownFields()
+	for i, entry := range entries {
+		for _, name := range sortedKeys(entry) {
+			if !known[name] {
+				return fmt.Errorf("error definition %d: unknown field %q (line %d)", i, name, entry[name].Line)
+			}
 		}
 	}
+	return nil
+}
+
+// checkUnknownJSONFields is the JSON counterpart of checkUnknownYAMLFields.
+// Like encoding/json, field names are matched case-insensitively.
+func checkUnknownJSONFields(data []byte) error {
+	var entries []map[string]json.RawMessage
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil
+	}
 
-	// Validate error definitions
+	known := knownFields()
+	for i, entry := range entries {
+		for _, name := range sortedKeys(entry) {
+			if !known[strings.ToLower(name)] {
+				return fmt.Errorf("error definition %d: unknown field %q", i, name)
+			}
+		}
+	}
+	return nil
+}
+
+// sortedKeys returns the keys of m in sorted order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// validate checks that every error definition has the required fields set.
+func validate(errors []ErrorDefinition) error {
+	fieldOwners := make(map[string]string)
+	uuidOwners := make(map[string]string)
 	for i, errDef := range errors {
 		if errDef.Code == 0 {
-			return nil, fmt.Errorf("error definition %d: code cannot be 0", i)
+			return fmt.Errorf("error definition %d: code cannot be 0", i)
 		}
 		if errDef.Key == "" {
-			return nil, fmt.Errorf("error definition %d: key cannot be empty", i)
+			return fmt.Errorf("error definition %d: key cannot be empty", i)
 		}
 		if errDef.Message == "" {
-			return nil, fmt.Errorf("error definition %d: message cannot be empty", i)
+			return fmt.Errorf("error definition %d: message cannot be empty", i)
+		}
+		if strings.TrimSpace(errDef.Key) != errDef.Key {
+			return fmt.Errorf("error definition %d: key %q has leading or trailing whitespace", i, errDef.Key)
+		}
+		if strings.TrimSpace(errDef.Message) != errDef.Message {
+			return fmt.Errorf("error definition %d: message %q has leading or trailing whitespace", i, errDef.Message)
 		}
 		if errDef.HTTP == 0 {
-			return nil, fmt.Errorf("error definition %d: http code cannot be 0", i)
+			return fmt.Errorf("error definition %d: http code cannot be 0", i)
 		}
 		if errDef.GRPC < 0 || errDef.GRPC > 16 {
-			return nil, fmt.Errorf("error definition %d: grpc code must be between 0 and 16", i)
+			return fmt.Errorf("error definition %d: grpc code must be between 0 and 16", i)
+		}
+		if errDef.Category != "" && !token.IsIdentifier(errDef.Category) {
+			return fmt.Errorf("error definition %d: category %q is not a valid Go identifier", i, errDef.Category)
+		}
+		if errDef.Group != "" && !token.IsIdentifier(errDef.Group) {
+			return fmt.Errorf("error definition %d: group %q is not a valid Go identifier", i, errDef.Group)
+		}
+		for _, field := range errDef.Fields {
+			if field == "" {
+				return fmt.Errorf("error definition %d: fields cannot contain an empty name", i)
+			}
+			if owner, exists := fieldOwners[field]; exists {
+				return fmt.Errorf("error definition %d: field %q is already mapped to %s", i, field, owner)
+			}
+			fieldOwners[field] = errDef.Key
+		}
+		if errDef.UUID != "" {
+			if !uuidPattern.MatchString(errDef.UUID) {
+				return fmt.Errorf("error definition %d: uuid %q is not of the form xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx", i, errDef.UUID)
+			}
+			id := strings.ToLower(errDef.UUID)
+			if owner, exists := uuidOwners[id]; exists {
+				return fmt.Errorf("error definition %d: uuid %s is already used by %s", i, id, owner)
+			}
+			uuidOwners[id] = errDef.Key
 		}
 	}
 
-	return errors, nil
+	return validateReplacements(errors)
+}
+
+// validateReplacements checks that every replaced_by names another defined
+// key and that replacements do not form a cycle.
+func validateReplacements(errors []ErrorDefinition) error {
+	replacedBy := make(map[string]string)
+	for _, errDef := range errors {
+		replacedBy[errDef.Key] = errDef.ReplacedBy
+	}
+
+	for i, errDef := range errors {
+		if errDef.ReplacedBy == "" {
+			continue
+		}
+		if _, exists := replacedBy[errDef.ReplacedBy]; !exists {
+			return fmt.Errorf("error definition %d: replaced_by %q is not a defined key", i, errDef.ReplacedBy)
+		}
+		seen := map[string]bool{errDef.Key: true}
+		for key := errDef.ReplacedBy; key != ""; key = replacedBy[key] {
+			if seen[key] {
+				return fmt.Errorf("error definition %d: replaced_by chain from %s loops back to %s", i, errDef.Key, key)
+			}
+			seen[key] = true
+		}
+	}
+
+	return nil
+}
+
+// canonicalKey follows replaced_by from errDef to the final replacement key.
+func canonicalKey(errors []ErrorDefinition, errDef ErrorDefinition) string {
+	replacedBy := make(map[string]string)
+	for _, d := range errors {
+		replacedBy[d.Key] = d.ReplacedBy
+	}
+
+	key := errDef.Key
+	for replacedBy[key] != "" {
+		key = replacedBy[key]
+	}
+	return key
+}
+
+// ParseCodeRange parses a code range of the form "min-max", e.g. "20000-20999".
+func ParseCodeRange(s string) (min, max uint64, err error) {
+	lo, hi, found := strings.Cut(s, "-")
+	if !found {
+		return 0, 0, fmt.Errorf("invalid code range %q: expected format min-max", s)
+	}
+	if min, err = strconv.ParseUint(strings.TrimSpace(lo), 10, 64); err != nil {
+		return 0, 0, fmt.Errorf("invalid code range %q: %w", s, err)
+	}
+	if max, err = strconv.ParseUint(strings.TrimSpace(hi), 10, 64); err != nil {
+		return 0, 0, fmt.Errorf("invalid code range %q: %w", s, err)
+	}
+	if min > max {
+		return 0, 0, fmt.Errorf("invalid code range %q: min is greater than max", s)
+	}
+	return min, max, nil
+}
+
+// ValidateCodeRange checks that every definition's code falls within
+// [min, max], returning an error listing all out-of-range entries.
+func ValidateCodeRange(errors []ErrorDefinition, min, max uint64) error {
+	var outOfRange []string
+	for _, errDef := range errors {
+		if errDef.Code < min || errDef.Code > max {
+			outOfRange = append(outOfRange, fmt.Sprintf("%s (%d)", errDef.Key, errDef.Code))
+		}
+	}
+	if len(outOfRange) > 0 {
+		return fmt.Errorf("codes outside range %d-%d: %s", min, max, strings.Join(outOfRange, ", "))
+	}
+	return nil
+}
+
+// ValidateNoOKCodes checks that no definition uses gRPC code 0 (OK),
+// returning an error listing all entries that do. OK is the success code: an
+// RC carrying it gives GRPCStatus() a status that gRPC treats as no error, so
+// callers would see the failure vanish. Catalogs that deliberately model
+// success outcomes can skip this check.
+func ValidateNoOKCodes(errors []ErrorDefinition) error {
+	var ok []string
+	for _, errDef := range errors {
+		if errDef.GRPC == 0 {
+			ok = append(ok, fmt.Sprintf("%s (%d)", errDef.Key, errDef.Code))
+		}
+	}
+	if len(ok) > 0 {
+		return fmt.Errorf("grpc code 0 (OK) is a success code, not an error: %s", strings.Join(ok, ", "))
+	}
+	return nil
+}
+
+// ValidateCodePrefix checks that every definition's code, written in decimal,
+// starts with prefix, returning an error listing all entries that do not.
+func ValidateCodePrefix(errors []ErrorDefinition, prefix string) error {
+	return validateCodeShape(errors, fmt.Sprintf("codes not starting with %s", prefix), func(code string) bool {
+		return strings.HasPrefix(code, prefix)
+	})
+}
+
+// ValidateCodePattern checks that every definition's code, written in
+// decimal, matches the regular expression pattern in full, returning an
+// error listing all entries that do not.
+func ValidateCodePattern(errors []ErrorDefinition, pattern string) error {
+	re, err := regexp.Compile("^(?:" + pattern + ")$")
+	if err != nil {
+		return fmt.Errorf("invalid code pattern %q: %w", pattern, err)
+	}
+	return validateCodeShape(errors, fmt.Sprintf("codes not matching %s", pattern), re.MatchString)
+}
+
+// validateCodeShape returns an error starting with what and listing every
+// definition whose decimal code is rejected by match.
+func validateCodeShape(errors []ErrorDefinition, what string, match func(code string) bool) error {
+	var mismatched []string
+	for _, errDef := range errors {
+		if !match(strconv.FormatUint(errDef.Code, 10)) {
+			mismatched = append(mismatched, fmt.Sprintf("%s (%d)", errDef.Key, errDef.Code))
+		}
+	}
+	if len(mismatched) > 0 {
+		return fmt.Errorf("%s: %s", what, strings.Join(mismatched, ", "))
+	}
+	return nil
+}
+
+// GenerateSplit generates a package split across files by group. The result
+// maps each group name to a file declaring only that group's errors, and ""
+// to a file holding the ungrouped errors and every package-wide declaration
+// (the Keys slice, KeyForCode, the Code enumeration and so on), which still
+// cover the whole catalog. The "" file is omitted when it would be empty.
+func GenerateSplit(config Config) (map[string][]byte, error) {
+	if config.Template != "" {
+		return nil, fmt.Errorf("split output cannot be used with a template")
+	}
+
+	groups, grouped := groupDefinitions(config.Errors)
+	var ungrouped []ErrorDefinition
+	for _, errDef := range config.Errors {
+		if errDef.Group == "" {
+			ungrouped = append(ungrouped, errDef)
+		}
+	}
+
+	files := make(map[string][]byte)
+	for _, group := range groups {
+		part := config
+		part.shard = &shard{declared: grouped[group]}
+		code, err := Generate(part)
+		if err != nil {
+			return nil, err
+		}
+		files[group] = code
+	}
+
+	main := config
+	main.shard = &shard{declared: ungrouped, catalog: true}
+	code, err := Generate(main)
+	if err != nil {
+		return nil, err
+	}
+	if len(ungrouped) > 0 || len(groups) == 0 || hasCatalogDeclarations(config) {
+		files[""] = code
+	}
+
+	return files, nil
+}
+
+// hasCatalogDeclarations reports whether config emits any package-wide
+// declaration.
+func hasCatalogDeclarations(config Config) bool {
+	return config.TypedConstants || config.Validation || config.Keys || config.KeyForCode ||
+		config.CLI || config.Decoder || config.CountGuard || config.CodeEnum || config.SSE ||
+		hasReplacements(config.Errors)
+}
+
+// ValidateMessageLength checks that no definition's message is longer than
+// max characters, returning an error naming the first entry that is.
+func ValidateMessageLength(errors []ErrorDefinition, max int) error {
+	for i, errDef := range errors {
+		if n := utf8.RuneCountInString(errDef.Message); n > max {
+			return fmt.Errorf("error definition %d: message of %s is %d characters, longer than the maximum of %d", i, errDef.Key, n, max)
+		}
+	}
+	return nil
+}
+
+// GroupByCategory splits error definitions by their category. Definitions
+// without a category are returned separately, and the category names are
+// returned sorted for deterministic output.
+func GroupByCategory(errors []ErrorDefinition) (uncategorized []ErrorDefinition, categories []string, grouped map[string][]ErrorDefinition) {
+	grouped = make(map[string][]ErrorDefinition)
+	for _, errDef := range errors {
+		if errDef.Category == "" {
+			uncategorized = append(uncategorized, errDef)
+			continue
+		}
+		if _, exists := grouped[errDef.Category]; !exists {
+			categories = append(categories, errDef.Category)
+		}
+		grouped[errDef.Category] = append(grouped[errDef.Category], errDef)
+	}
+	sort.Strings(categories)
+
+	return uncategorized, categories, grouped
 }
 
 // Generate creates Go source code from the error definitions.
@@ -84,47 +588,395 @@ func Generate(config Config) ([]byte, error) {
 		config.Package = "main"
 	}
 
-	var builder strings.Builder
+	if err := checkSymbols(config); err != nil {
+		return nil, err
+	}
 
-	// Write package declaration
-	builder.WriteString(fmt.Sprintf("// Code generated by rescodegen. DO NOT EDIT.\n\n"))
-	builder.WriteString(fmt.Sprintf("package %s\n\n", config.Package))
+	codeType, err := checkCodeType(config)
+	if err != nil {
+		return nil, err
+	}
 
-	// Write imports
-	builder.WriteString("import (\n")
-	builder.WriteString("\t\"github.com/restayway/rescode\"\n")
-	builder.WriteString("\t\"google.golang.org/grpc/codes\"\n")
-	builder.WriteString(")\n\n")
+	if err := checkDataExamples(config.Errors); err != nil {
+		return nil, err
+	}
+
+	if config.Template != "" {
+		return generateFromTemplate(config)
+	}
+
+	declared, catalog := config.Errors, true
+	if config.shard != nil {
+		declared, catalog = config.shard.declared, config.shard.catalog
+	}
+
+	var builder strings.Builder
+	stdImports := make(map[string]bool)
+
+	httpType, httpArg := "int", "%sHTTP"
+	if config.TypedConstants {
+		httpType, httpArg = "HTTPStatus", "int(%sHTTP)"
+	}
+	if config.TypedConstants && catalog {
+		builder.WriteString("// HTTPStatus is the HTTP status code of an error in this package.\n")
+		builder.WriteString("type HTTPStatus int\n\n")
+	}
 
 	// Generate constants for each error
 	builder.WriteString("// Error code constants\n")
 	builder.WriteString("const (\n")
-	for _, errDef := range config.Errors {
-		builder.WriteString(fmt.Sprintf("\t%sCode uint64 = %d\n", errDef.Key, errDef.Code))
-		builder.WriteString(fmt.Sprintf("\t%sHTTP int = %d\n", errDef.Key, errDef.HTTP))
-		builder.WriteString(fmt.Sprintf("\t%sGRPC codes.Code = %d\n", errDef.Key, errDef.GRPC))
-		builder.WriteString(fmt.Sprintf("\t%sMsg string = %q\n", errDef.Key, errDef.Message))
+	for _, errDef := range declared {
+		// Each constant needs its own doc comment for tools to flag its use
+		var deprecated string
+		if notice := deprecationNotice(errDef); notice != "" {
+			deprecated = "\t// " + notice + "\n"
+		}
+		builder.WriteString(fmt.Sprintf("%s\t%sCode %s = %d\n", deprecated, errDef.Key, codeType, errDef.Code))
+		builder.WriteString(fmt.Sprintf("%s\t%sHTTP %s = %d\n", deprecated, errDef.Key, httpType, errDef.HTTP))
+		builder.WriteString(fmt.Sprintf("%s\t%sGRPC codes.Code = %d\n", deprecated, errDef.Key, errDef.GRPC))
+		builder.WriteString(fmt.Sprintf("%s\t%sMsg string = %q\n", deprecated, errDef.Key, errDef.Message))
 		if errDef.Desc != "" {
-			builder.WriteString(fmt.Sprintf("\t%sDesc string = %q\n", errDef.Key, errDef.Desc))
+			builder.WriteString(fmt.Sprintf("%s\t%sDesc string = %q\n", deprecated, errDef.Key, errDef.Desc))
+		}
+		if errDef.Suggestion != "" {
+			builder.WriteString(fmt.Sprintf("%s\t%sSuggestion string = %q\n", deprecated, errDef.Key, errDef.Suggestion))
+		}
+		if id := errorUUID(config, errDef); id != "" {
+			builder.WriteString(fmt.Sprintf("%s\t%sUUID string = %q\n", deprecated, errDef.Key, id))
 		}
 		builder.WriteString("\n")
 	}
 	builder.WriteString(")\n\n")
 
 	// Generate factory functions
-	for _, errDef := range config.Errors {
+	codeArg := "%sCode"
+	if codeType != "uint64" {
+		codeArg = "uint64(%sCode)"
+	}
+	for _, errDef := range declared {
 		builder.WriteString(fmt.Sprintf("// %s creates a new %s error.\n", errDef.Key, errDef.Key))
 		if errDef.Desc != "" {
-			builder.WriteString(fmt.Sprintf("// %s\n", errDef.Desc))
+			builder.WriteString(docComment(errDef.Desc))
+		}
+		if notice := deprecationNotice(errDef); notice != "" {
+			builder.WriteString("//\n// " + notice + "\n")
+		}
+		var setters string
+		if errDef.Suggestion != "" {
+			setters = fmt.Sprintf(".WithSuggestion(%sSuggestion)", errDef.Key)
+		}
+		if errorUUID(config, errDef) != "" {
+			setters += fmt.Sprintf(".WithUUID(%sUUID)", errDef.Key)
+		}
+		var data string
+		if errDef.DataExample != nil {
+			lit, err := goLiteral(errDef.DataExample)
+			if err != nil {
+				return nil, fmt.Errorf("key %s: %w", errDef.Key, err)
+			}
+			data = ", " + lit
 		}
+		creator := fmt.Sprintf("rescode.New(%s, %s, %sGRPC, %sMsg%s)",
+			fmt.Sprintf(codeArg, errDef.Key), fmt.Sprintf(httpArg, errDef.Key), errDef.Key, errDef.Key, data)
 		builder.WriteString(fmt.Sprintf("func %s(err ...error) *rescode.RC {\n", errDef.Key))
-		builder.WriteString(fmt.Sprintf("\treturn rescode.New(%sCode, %sHTTP, %sGRPC, %sMsg)(err...)\n",
-			errDef.Key, errDef.Key, errDef.Key, errDef.Key))
+		builder.WriteString(fmt.Sprintf("\treturn %s(err...)%s\n", creator, setters))
 		builder.WriteString("}\n\n")
 	}
 
+	// Generate grouped accessors
+	groups, grouped := groupDefinitions(declared)
+	for _, group := range groups {
+		builder.WriteString(fmt.Sprintf("// %s groups the %s errors.\n", group, group))
+		builder.WriteString(fmt.Sprintf("var %s = struct {\n", group))
+		for _, errDef := range grouped[group] {
+			builder.WriteString(fmt.Sprintf("\t%s rescode.RcCreator\n", errDef.Key))
+		}
+		builder.WriteString("}{\n")
+		for _, errDef := range grouped[group] {
+			builder.WriteString(fmt.Sprintf("\t%s: %s,\n", errDef.Key, errDef.Key))
+		}
+		builder.WriteString("}\n\n")
+	}
+
+	// Generate Must factories
+	if config.Must {
+		for _, errDef := range declared {
+			builder.WriteString(fmt.Sprintf("// Must%s creates a new %s error wrapping err.\n", errDef.Key, errDef.Key))
+			builder.WriteString("// It panics if err is nil, for code paths where a cause is always expected.\n")
+			builder.WriteString(fmt.Sprintf("func Must%s(err error) *rescode.RC {\n", errDef.Key))
+			builder.WriteString("\tif err == nil {\n")
+			builder.WriteString(fmt.Sprintf("\t\tpanic(%q)\n", "rescode: Must"+errDef.Key+" called with nil error"))
+			builder.WriteString("\t}\n")
+			builder.WriteString(fmt.Sprintf("\treturn %s(err)\n", errDef.Key))
+			builder.WriteString("}\n\n")
+		}
+	}
+
+	// Generate typed response constructors
+	if config.Responses {
+		for _, errDef := range declared {
+			builder.WriteString(fmt.Sprintf("// %sResponse returns the response body for a %s error.\n", errDef.Key, errDef.Key))
+			builder.WriteString(fmt.Sprintf("func %sResponse() rescode.Response {\n", errDef.Key))
+			builder.WriteString(fmt.Sprintf("\treturn %s().Response()\n", errDef.Key))
+			builder.WriteString("}\n\n")
+		}
+	}
+
+	// Generate the validation field mapping
+	if catalog && config.Validation {
+		fallback := "nil"
+		var cases strings.Builder
+		for _, errDef := range config.Errors {
+			var fields []string
+			for _, field := range errDef.Fields {
+				if field == "*" {
+					fallback = errDef.Key + "()"
+					continue
+				}
+				fields = append(fields, strconv.Quote(field))
+			}
+			if len(fields) > 0 {
+				cases.WriteString(fmt.Sprintf("\tcase %s:\n", strings.Join(fields, ", ")))
+				cases.WriteString(fmt.Sprintf("\t\treturn %s()\n", errDef.Key))
+			}
+		}
+
+		builder.WriteString("// ValidationError returns the error mapped to a failed request field through\n")
+		builder.WriteString("// the fields list of its definition. Unmapped fields return the \"*\" entry,\n")
+		builder.WriteString("// or nil if there is none.\n")
+		builder.WriteString("func ValidationError(field string) *rescode.RC {\n")
+		if cases.Len() > 0 {
+			builder.WriteString("\tswitch field {\n")
+			builder.WriteString(cases.String())
+			builder.WriteString("\t}\n")
+		}
+		builder.WriteString(fmt.Sprintf("\treturn %s\n", fallback))
+		builder.WriteString("}\n\n")
+	}
+
+	// Generate the canonical code lookup for replaced codes
+	if catalog && hasReplacements(config.Errors) {
+		builder.WriteString("// Canonical returns the code that replaces a deprecated code, following\n")
+		builder.WriteString("// replaced_by to the final replacement. Other codes are returned unchanged.\n")
+		builder.WriteString("func Canonical(code uint64) uint64 {\n")
+		builder.WriteString("\tswitch code {\n")
+		for _, errDef := range config.Errors {
+			if errDef.ReplacedBy == "" {
+				continue
+			}
+			builder.WriteString(fmt.Sprintf("\tcase %s:\n", fmt.Sprintf(codeArg, errDef.Key)))
+			builder.WriteString(fmt.Sprintf("\t\treturn %s\n", fmt.Sprintf(codeArg, canonicalKey(config.Errors, errDef))))
+		}
+		builder.WriteString("\t}\n")
+		builder.WriteString("\treturn code\n")
+		builder.WriteString("}\n\n")
+	}
+
+	// Generate sentinel errors
+	if config.Sentinels {
+		builder.WriteString("// Sentinel errors for comparison with errors.Is\n")
+		builder.WriteString("var (\n")
+		for _, errDef := range declared {
+			builder.WriteString(fmt.Sprintf("\tErr%s = %s()\n", errDef.Key, errDef.Key))
+		}
+		builder.WriteString(")\n\n")
+	}
+
+	// Generate the sorted key list
+	if catalog && config.Keys {
+		keys := make([]string, 0, len(config.Errors))
+		for _, errDef := range config.Errors {
+			keys = append(keys, errDef.Key)
+		}
+		sort.Strings(keys)
+
+		builder.WriteString("// Keys lists every error key in sorted order.\n")
+		builder.WriteString("var Keys = []string{\n")
+		for _, key := range keys {
+			builder.WriteString(fmt.Sprintf("\t%q,\n", key))
+		}
+		builder.WriteString("}\n\n")
+	}
+
+	// Generate the code-to-key lookup
+	if catalog && config.KeyForCode {
+		stdImports["strconv"] = true
+
+		builder.WriteString("// KeyForCode returns the key of the error with the given code, or\n")
+		builder.WriteString("// \"UNKNOWN(code)\" if no error in this package has that code.\n")
+		builder.WriteString("func KeyForCode(code uint64) string {\n")
+		builder.WriteString("\tswitch code {\n")
+		for _, errDef := range config.Errors {
+			builder.WriteString(fmt.Sprintf("\tcase %s:\n", fmt.Sprintf(codeArg, errDef.Key)))
+			builder.WriteString(fmt.Sprintf("\t\treturn %q\n", errDef.Key))
+		}
+		builder.WriteString("\t}\n")
+		builder.WriteString("\treturn \"UNKNOWN(\" + strconv.FormatUint(code, 10) + \")\"\n")
+		builder.WriteString("}\n\n")
+	}
+
+	// Generate the errors CLI subcommand
+	if catalog && config.CLI {
+		stdImports["fmt"], stdImports["io"], stdImports["text/tabwriter"] = true, true, true
+
+		builder.WriteString("// ErrorsCommand implements an \"errors\" CLI subcommand for inspecting the\n")
+		builder.WriteString("// catalog. \"list\", the default, prints every error's code, key and message\n")
+		builder.WriteString("// as a table to w.\n")
+		builder.WriteString("func ErrorsCommand(args []string, w io.Writer) error {\n")
+		builder.WriteString("\tif len(args) > 0 && args[0] != \"list\" {\n")
+		builder.WriteString("\t\treturn fmt.Errorf(\"unknown errors subcommand %q\", args[0])\n")
+		builder.WriteString("\t}\n\n")
+		builder.WriteString("\tcatalog := []struct {\n")
+		builder.WriteString("\t\tkey    string\n")
+		builder.WriteString("\t\tcreate rescode.RcCreator\n")
+		builder.WriteString("\t}{\n")
+		for _, errDef := range config.Errors {
+			builder.WriteString(fmt.Sprintf("\t\t{%q, %s},\n", errDef.Key, errDef.Key))
+		}
+		builder.WriteString("\t}\n\n")
+		builder.WriteString("\ttw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)\n")
+		builder.WriteString("\tfmt.Fprintln(tw, \"CODE\\tKEY\\tMESSAGE\")\n")
+		builder.WriteString("\tfor _, entry := range catalog {\n")
+		builder.WriteString("\t\trc := entry.create()\n")
+		builder.WriteString("\t\tfmt.Fprintf(tw, \"%d\\t%s\\t%s\\n\", rc.Code, entry.key, rc.Message)\n")
+		builder.WriteString("\t}\n")
+		builder.WriteString("\treturn tw.Flush()\n")
+		builder.WriteString("}\n\n")
+	}
+
+	// Generate the JSON error decoder
+	if catalog && config.Decoder {
+		stdImports["encoding/json"] = true
+
+		builder.WriteString("// DecodeError parses an error response body in the rescode JSON shape. Codes\n")
+		builder.WriteString("// from this catalog are rebuilt by their factory, restoring the canonical\n")
+		builder.WriteString("// message and HTTP and gRPC codes; other codes keep the values in the body.\n")
+		builder.WriteString("// Data from the body is kept in both cases.\n")
+		builder.WriteString("func DecodeError(body []byte) (*rescode.RC, error) {\n")
+		builder.WriteString("\tvar payload struct {\n")
+		builder.WriteString("\t\tCode     uint64 `json:\"code\"`\n")
+		builder.WriteString("\t\tMessage  string `json:\"message\"`\n")
+		builder.WriteString("\t\tHTTPCode int    `json:\"httpCode\"`\n")
+		builder.WriteString("\t\tRPCCode  int    `json:\"rpcCode\"`\n")
+		builder.WriteString("\t\tData     any    `json:\"data\"`\n")
+		builder.WriteString("\t}\n")
+		builder.WriteString("\tif err := json.Unmarshal(body, &payload); err != nil {\n")
+		builder.WriteString("\t\treturn nil, err\n")
+		builder.WriteString("\t}\n\n")
+		builder.WriteString("\tvar rc *rescode.RC\n")
+		builder.WriteString("\tswitch payload.Code {\n")
+		for _, errDef := range config.Errors {
+			builder.WriteString(fmt.Sprintf("\tcase %s:\n", fmt.Sprintf(codeArg, errDef.Key)))
+			builder.WriteString(fmt.Sprintf("\t\trc = %s()\n", errDef.Key))
+		}
+		builder.WriteString("\tdefault:\n")
+		builder.WriteString("\t\trc = rescode.New(payload.Code, payload.HTTPCode, codes.Code(payload.RPCCode), payload.Message)()\n")
+		builder.WriteString("\t}\n")
+		builder.WriteString("\tif payload.Data != nil {\n")
+		builder.WriteString("\t\trc.SetData(payload.Data)\n")
+		builder.WriteString("\t}\n")
+		builder.WriteString("\treturn rc, nil\n")
+		builder.WriteString("}\n\n")
+	}
+
+	// Generate the factory count guard
+	if catalog && config.CountGuard {
+		builder.WriteString("// ErrorCount is the number of error definitions in this package.\n")
+		builder.WriteString(fmt.Sprintf("const ErrorCount = %d\n\n", len(config.Errors)))
+		builder.WriteString("// Compile-time check that every factory is accounted for in ErrorCount.\n")
+		builder.WriteString("var _ = [ErrorCount]rescode.RcCreator{\n")
+		for _, errDef := range config.Errors {
+			builder.WriteString(fmt.Sprintf("\t%s,\n", errDef.Key))
+		}
+		builder.WriteString("}\n\n")
+	}
+
+	// Generate the typed code enumeration
+	if catalog && config.CodeEnum {
+		stdImports["strconv"] = true
+
+		builder.WriteString("// Code is a typed enumeration of the error codes in this package.\n")
+		builder.WriteString(fmt.Sprintf("type Code %s\n\n", codeType))
+
+		builder.WriteString("// Code enumeration values\n")
+		builder.WriteString("const (\n")
+		for _, errDef := range config.Errors {
+			builder.WriteString(fmt.Sprintf("\tCode%s Code = Code(%sCode)\n", errDef.Key, errDef.Key))
+		}
+		builder.WriteString(")\n\n")
+
+		builder.WriteString("// AllCodes returns every error code in definition order.\n")
+		builder.WriteString("func AllCodes() []Code {\n")
+		builder.WriteString("\treturn []Code{\n")
+		for _, errDef := range config.Errors {
+			builder.WriteString(fmt.Sprintf("\t\tCode%s,\n", errDef.Key))
+		}
+		builder.WriteString("\t}\n")
+		builder.WriteString("}\n\n")
+
+		builder.WriteString("// String returns the key of the error code.\n")
+		builder.WriteString("func (c Code) String() string {\n")
+		builder.WriteString("\tswitch c {\n")
+		for _, errDef := range config.Errors {
+			builder.WriteString(fmt.Sprintf("\tcase Code%s:\n", errDef.Key))
+			builder.WriteString(fmt.Sprintf("\t\treturn %q\n", errDef.Key))
+		}
+		builder.WriteString("\t}\n")
+		builder.WriteString("\treturn \"Code(\" + strconv.FormatUint(uint64(c), 10) + \")\"\n")
+		builder.WriteString("}\n\n")
+	}
+
+	// Generate the Server-Sent Events catalog handler
+	if catalog && config.SSE {
+		stdImports["encoding/json"], stdImports["fmt"], stdImports["net/http"] = true, true, true
+
+		builder.WriteString("// CatalogSSE writes every error in the catalog to w as a Server-Sent Events\n")
+		builder.WriteString("// stream, one data event per error carrying its JSON representation.\n")
+		builder.WriteString("func CatalogSSE(w http.ResponseWriter) {\n")
+		builder.WriteString("\tw.Header().Set(\"Content-Type\", \"text/event-stream\")\n")
+		builder.WriteString("\tw.Header().Set(\"Cache-Control\", \"no-cache\")\n")
+		builder.WriteString("\tflusher, _ := w.(http.Flusher)\n\n")
+		builder.WriteString("\tcatalog := []rescode.RcCreator{\n")
+		for _, errDef := range config.Errors {
+			builder.WriteString(fmt.Sprintf("\t\t%s,\n", errDef.Key))
+		}
+		builder.WriteString("\t}\n")
+		builder.WriteString("\tfor _, create := range catalog {\n")
+		builder.WriteString("\t\tdata, err := json.Marshal(create())\n")
+		builder.WriteString("\t\tif err != nil {\n")
+		builder.WriteString("\t\t\tcontinue\n")
+		builder.WriteString("\t\t}\n")
+		builder.WriteString("\t\tfmt.Fprintf(w, \"data: %s\\n\\n\", data)\n")
+		builder.WriteString("\t\tif flusher != nil {\n")
+		builder.WriteString("\t\t\tflusher.Flush()\n")
+		builder.WriteString("\t\t}\n")
+		builder.WriteString("\t}\n")
+		builder.WriteString("}\n\n")
+	}
+
+	// Write package declaration and imports
+	var header strings.Builder
+	header.WriteString(fileHeader(config))
+	header.WriteString(fmt.Sprintf("package %s\n\n", config.Package))
+	header.WriteString("import (\n")
+	for _, imp := range sortedKeys(stdImports) {
+		header.WriteString(fmt.Sprintf("\t%q\n", imp))
+	}
+	if len(stdImports) > 0 {
+		header.WriteString("\n")
+	}
+	// A file of a split package may not reference both packages
+	body := builder.String()
+	if config.shard == nil || strings.Contains(body, "rescode.") {
+		header.WriteString(rescodeImport(config))
+	}
+	if config.shard == nil || strings.Contains(body, "codes.") {
+		header.WriteString("\t\"google.golang.org/grpc/codes\"\n")
+	}
+	header.WriteString(")\n\n")
+
 	// Format the generated code
-	source := builder.String()
+	source := header.String() + body
 	formatted, err := format.Source([]byte(source))
 	if err != nil {
 		return nil, fmt.Errorf("failed to format generated code: %w", err)
@@ -132,3 +984,415 @@ func Generate(config Config) ([]byte, error) {
 
 	return formatted, nil
 }
+
+// emittedSymbols returns the top-level identifiers Generate emits for errDef.
+func emittedSymbols(config Config, errDef ErrorDefinition) []string {
+	key := errDef.Key
+	symbols := []string{key, key + "Code", key + "HTTP", key + "GRPC", key + "Msg"}
+	if errDef.Desc != "" {
+		symbols = append(symbols, key+"Desc")
+	}
+	if errDef.Suggestion != "" {
+		symbols = append(symbols, key+"Suggestion")
+	}
+	if errorUUID(config, errDef) != "" {
+		symbols = append(symbols, key+"UUID")
+	}
+	if config.Sentinels {
+		symbols = append(symbols, "Err"+key)
+	}
+	if config.CodeEnum {
+		symbols = append(symbols, "Code"+key)
+	}
+	if config.Must {
+		symbols = append(symbols, "Must"+key)
+	}
+	if config.Responses {
+		symbols = append(symbols, key+"Response")
+	}
+	return symbols
+}
+
+// groupDefinitions returns the sorted group names and the definitions in
+// each group. Definitions without a group are omitted.
+func groupDefinitions(errors []ErrorDefinition) ([]string, map[string][]ErrorDefinition) {
+	var groups []string
+	grouped := make(map[string][]ErrorDefinition)
+	for _, errDef := range errors {
+		if errDef.Group == "" {
+			continue
+		}
+		if _, exists := grouped[errDef.Group]; !exists {
+			groups = append(groups, errDef.Group)
+		}
+		grouped[errDef.Group] = append(grouped[errDef.Group], errDef)
+	}
+	sort.Strings(groups)
+
+	return groups, grouped
+}
+
+// checkSymbols fails if two definitions would emit the same identifier, e.g.
+// the constant FooMsg for key Foo and the factory for key FooMsg, or share a
+// code, which would give the generated switches on code duplicate cases.
+func checkSymbols(config Config) error {
+	owners := make(map[string]string)
+	if config.CodeEnum {
+		owners["Code"] = "the Code enumeration"
+		owners["AllCodes"] = "the Code enumeration"
+	}
+	if config.TypedConstants {
+		owners["HTTPStatus"] = "the HTTPStatus type"
+	}
+	if config.CountGuard {
+		owners["ErrorCount"] = "the ErrorCount constant"
+	}
+	if config.SSE {
+		owners["CatalogSSE"] = "the CatalogSSE handler"
+	}
+	if config.Keys {
+		owners["Keys"] = "the Keys slice"
+	}
+	if config.Validation {
+		owners["ValidationError"] = "the ValidationError function"
+	}
+	if config.KeyForCode {
+		owners["KeyForCode"] = "the KeyForCode function"
+	}
+	if config.CLI {
+		owners["ErrorsCommand"] = "the ErrorsCommand function"
+	}
+	if config.Decoder {
+		owners["DecodeError"] = "the DecodeError function"
+	}
+	if hasReplacements(config.Errors) {
+		owners["Canonical"] = "the Canonical function"
+	}
+	groups, _ := groupDefinitions(config.Errors)
+	for _, group := range groups {
+		if existing, exists := owners[group]; exists {
+			return fmt.Errorf("group %s collides with %s", group, existing)
+		}
+		owners[group] = "group " + group
+	}
+
+	codeOwners := make(map[uint64]string)
+	for _, errDef := range config.Errors {
+		if existing, exists := codeOwners[errDef.Code]; exists {
+			return fmt.Errorf("code %d of key %s is already used by key %s", errDef.Code, errDef.Key, existing)
+		}
+		codeOwners[errDef.Code] = errDef.Key
+
+		owner := "key " + errDef.Key
+		for _, symbol := range emittedSymbols(config, errDef) {
+			if existing, exists := owners[symbol]; exists {
+				if existing == owner {
+					return fmt.Errorf("duplicate key %s", errDef.Key)
+				}
+				return fmt.Errorf("generated symbol %s from key %s collides with %s", symbol, errDef.Key, existing)
+			}
+			owners[symbol] = owner
+		}
+	}
+
+	return nil
+}
+
+// rescodeImport returns the import line for the rescode package, named
+// rescode explicitly when the import path ends in another element.
+func rescodeImport(config Config) string {
+	importPath := config.ImportPath
+	if importPath == "" {
+		importPath = DefaultImportPath
+	}
+	if path.Base(importPath) != "rescode" {
+		return fmt.Sprintf("\trescode %q\n", importPath)
+	}
+	return fmt.Sprintf("\t%q\n", importPath)
+}
+
+// docComment renders text as // comment lines, one per line of text, keeping
+// indentation and blank lines so multi-line descriptions read as written.
+// Trailing newlines, as left by YAML block scalars, are dropped.
+func docComment(text string) string {
+	var b strings.Builder
+	for _, line := range strings.Split(strings.TrimRight(text, "\r\n"), "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		if line == "" {
+			b.WriteString("//\n")
+			continue
+		}
+		b.WriteString("// " + line + "\n")
+	}
+	return b.String()
+}
+
+// deprecationNotice returns the "Deprecated:" paragraph documenting a
+// deprecated definition, or "" if it is not deprecated. Without a reason it
+// points to the replaced_by key when there is one.
+func deprecationNotice(errDef ErrorDefinition) string {
+	if !errDef.Deprecated.Deprecated {
+		return ""
+	}
+	reason := strings.Join(strings.Fields(errDef.Deprecated.Reason), " ")
+	switch {
+	case reason != "":
+	case errDef.ReplacedBy != "":
+		reason = fmt.Sprintf("use %s instead.", errDef.ReplacedBy)
+	default:
+		reason = fmt.Sprintf("%s is kept for compatibility only.", errDef.Key)
+	}
+	return "Deprecated: " + reason
+}
+
+// hasReplacements reports whether any definition has a replaced_by.
+func hasReplacements(errors []ErrorDefinition) bool {
+	for _, errDef := range errors {
+		if errDef.ReplacedBy != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// codeTypeBits lists the supported CodeType values and their widths.
+var codeTypeBits = map[string]int{"uint8": 8, "uint16": 16, "uint32": 32, "uint64": 64}
+
+// checkCodeType returns the code type for config, defaulting to uint64, and
+// verifies that every code fits within it.
+func checkCodeType(config Config) (string, error) {
+	codeType := config.CodeType
+	if codeType == "" {
+		codeType = "uint64"
+	}
+
+	bits, ok := codeTypeBits[codeType]
+	if !ok {
+		return "", fmt.Errorf("unsupported code type %q: must be uint8, uint16, uint32 or uint64", config.CodeType)
+	}
+	for _, errDef := range config.Errors {
+		if bits < 64 && errDef.Code >= 1<<bits {
+			return "", fmt.Errorf("code %d of key %s does not fit in %s", errDef.Code, errDef.Key, codeType)
+		}
+	}
+
+	return codeType, nil
+}
+
+// generatedMarker is the standard comment identifying generated Go files, as
+// recognized by go vet, golangci-lint and code review tools.
+const generatedMarker = "// Code generated by rescodegen. DO NOT EDIT."
+
+// fileHeader returns config.Header as comment lines followed by the
+// generated-code marker.
+func fileHeader(config Config) string {
+	var b strings.Builder
+	if header := strings.TrimSpace(config.Header); header != "" {
+		for _, line := range strings.Split(header, "\n") {
+			line = strings.TrimRight(line, " \t\r")
+			switch {
+			case strings.HasPrefix(line, "//"):
+				b.WriteString(line)
+			case line == "":
+				b.WriteString("//")
+			default:
+				b.WriteString("// " + line)
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(generatedMarker + "\n\n")
+	return b.String()
+}
+
+// GenerateToWriter generates Go source code like Generate and writes it to w.
+// Formatting needs the complete source, so the output is still assembled in
+// memory before being written; nothing is written if generation fails.
+func GenerateToWriter(config Config, w io.Writer) error {
+	code, err := Generate(config)
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.Write(code); err != nil {
+		return fmt.Errorf("failed to write generated code: %w", err)
+	}
+
+	return nil
+}
+
+// GenerateFactoryTest creates a Go test file asserting that every generated
+// factory produces the code, HTTP status, gRPC code and message of its
+// definition. The expected values are literals taken from the definitions,
+// so an accidental edit to a generated constant fails the test.
+func GenerateFactoryTest(config Config) ([]byte, error) {
+	if config.Package == "" {
+		config.Package = "main"
+	}
+
+	var builder strings.Builder
+
+	builder.WriteString(fileHeader(config))
+	builder.WriteString(fmt.Sprintf("package %s\n\n", config.Package))
+
+	builder.WriteString("import (\n")
+	builder.WriteString("\t\"testing\"\n\n")
+	builder.WriteString(rescodeImport(config))
+	builder.WriteString("\t\"google.golang.org/grpc/codes\"\n")
+	builder.WriteString(")\n\n")
+
+	builder.WriteString("func TestGeneratedFactories(t *testing.T) {\n")
+	builder.WriteString("\ttests := []struct {\n")
+	builder.WriteString("\t\tname    string\n")
+	builder.WriteString("\t\tfactory func(...error) *rescode.RC\n")
+	builder.WriteString("\t\tcode    uint64\n")
+	builder.WriteString("\t\thttp    int\n")
+	builder.WriteString("\t\tgrpc    codes.Code\n")
+	builder.WriteString("\t\tmessage string\n")
+	builder.WriteString("\t}{\n")
+	for _, errDef := range config.Errors {
+		builder.WriteString(fmt.Sprintf("\t\t{%q, %s, %d, %d, %d, %q},\n", errDef.Key, errDef.Key, errDef.Code, errDef.HTTP, errDef.GRPC, errDef.Message))
+	}
+	builder.WriteString("\t}\n\n")
+	builder.WriteString("\tfor _, tt := range tests {\n")
+	builder.WriteString("\t\tt.Run(tt.name, func(t *testing.T) {\n")
+	builder.WriteString("\t\t\trc := tt.factory()\n")
+	builder.WriteString("\t\t\tif rc.Code != tt.code {\n")
+	builder.WriteString("\t\t\t\tt.Errorf(\"Expected Code %d, got %d\", tt.code, rc.Code)\n")
+	builder.WriteString("\t\t\t}\n")
+	builder.WriteString("\t\t\tif rc.HttpCode != tt.http {\n")
+	builder.WriteString("\t\t\t\tt.Errorf(\"Expected HttpCode %d, got %d\", tt.http, rc.HttpCode)\n")
+	builder.WriteString("\t\t\t}\n")
+	builder.WriteString("\t\t\tif rc.RpcCode != tt.grpc {\n")
+	builder.WriteString("\t\t\t\tt.Errorf(\"Expected RpcCode %d, got %d\", tt.grpc, rc.RpcCode)\n")
+	builder.WriteString("\t\t\t}\n")
+	builder.WriteString("\t\t\tif rc.Message != tt.message {\n")
+	builder.WriteString("\t\t\t\tt.Errorf(\"Expected Message %q, got %q\", tt.message, rc.Message)\n")
+	builder.WriteString("\t\t\t}\n")
+	builder.WriteString("\t\t})\n")
+	builder.WriteString("\t}\n")
+	builder.WriteString("}\n")
+
+	formatted, err := format.Source([]byte(builder.String()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated test: %w", err)
+	}
+
+	return formatted, nil
+}
+
+// GenerateGRPCRoundTripTest creates a Go test file asserting that every
+// generated factory's code and message survive a round trip through
+// rescode.FromGRPCStatus(rc.GRPCStatus()), including the wire encoding.
+// Factories with gRPC code OK must round-trip to nil, as an OK status is not
+// an error.
+func GenerateGRPCRoundTripTest(config Config) ([]byte, error) {
+	if config.Package == "" {
+		config.Package = "main"
+	}
+
+	var builder strings.Builder
+
+	builder.WriteString(fileHeader(config))
+	builder.WriteString(fmt.Sprintf("package %s\n\n", config.Package))
+
+	builder.WriteString("import (\n")
+	builder.WriteString("\t\"testing\"\n\n")
+	builder.WriteString(rescodeImport(config))
+	builder.WriteString("\t\"google.golang.org/grpc/codes\"\n")
+	builder.WriteString("\t\"google.golang.org/grpc/status\"\n")
+	builder.WriteString(")\n\n")
+
+	builder.WriteString("func TestGeneratedGRPCRoundTrip(t *testing.T) {\n")
+	builder.WriteString("\ttests := []struct {\n")
+	builder.WriteString("\t\tname    string\n")
+	builder.WriteString("\t\tfactory func(...error) *rescode.RC\n")
+	builder.WriteString("\t}{\n")
+	for _, errDef := range config.Errors {
+		builder.WriteString(fmt.Sprintf("\t\t{%q, %s},\n", errDef.Key, errDef.Key))
+	}
+	builder.WriteString("\t}\n\n")
+	builder.WriteString("\tfor _, tt := range tests {\n")
+	builder.WriteString("\t\tt.Run(tt.name, func(t *testing.T) {\n")
+	builder.WriteString("\t\t\trc := tt.factory()\n")
+	builder.WriteString("\t\t\tgot := rescode.FromGRPCStatus(status.FromProto(rc.GRPCStatus().Proto()))\n")
+	builder.WriteString("\t\t\tif rc.RpcCode == codes.OK {\n")
+	builder.WriteString("\t\t\t\tif got != nil {\n")
+	builder.WriteString("\t\t\t\t\tt.Errorf(\"Expected nil for an OK status, got %v\", got)\n")
+	builder.WriteString("\t\t\t\t}\n")
+	builder.WriteString("\t\t\t\treturn\n")
+	builder.WriteString("\t\t\t}\n")
+	builder.WriteString("\t\t\tif got == nil {\n")
+	builder.WriteString("\t\t\t\tt.Fatal(\"Expected an RC, got nil\")\n")
+	builder.WriteString("\t\t\t}\n")
+	builder.WriteString("\t\t\tif got.Code != rc.Code {\n")
+	builder.WriteString("\t\t\t\tt.Errorf(\"Expected Code %d, got %d\", rc.Code, got.Code)\n")
+	builder.WriteString("\t\t\t}\n")
+	builder.WriteString("\t\t\tif got.Message != rc.Message {\n")
+	builder.WriteString("\t\t\t\tt.Errorf(\"Expected Message %q, got %q\", rc.Message, got.Message)\n")
+	builder.WriteString("\t\t\t}\n")
+	builder.WriteString("\t\t\tif got.RpcCode != rc.RpcCode {\n")
+	builder.WriteString("\t\t\t\tt.Errorf(\"Expected RpcCode %v, got %v\", rc.RpcCode, got.RpcCode)\n")
+	builder.WriteString("\t\t\t}\n")
+	builder.WriteString("\t\t})\n")
+	builder.WriteString("\t}\n")
+	builder.WriteString("}\n")
+
+	formatted, err := format.Source([]byte(builder.String()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated test: %w", err)
+	}
+
+	return formatted, nil
+}
+
+// GenerateGRPCTest creates a Go test file asserting that every generated
+// factory's GRPCStatus() carries the expected gRPC code and message.
+func GenerateGRPCTest(config Config) ([]byte, error) {
+	if config.Package == "" {
+		config.Package = "main"
+	}
+
+	var builder strings.Builder
+
+	builder.WriteString(fileHeader(config))
+	builder.WriteString(fmt.Sprintf("package %s\n\n", config.Package))
+
+	builder.WriteString("import (\n")
+	builder.WriteString("\t\"testing\"\n\n")
+	builder.WriteString(rescodeImport(config))
+	builder.WriteString("\t\"google.golang.org/grpc/codes\"\n")
+	builder.WriteString(")\n\n")
+
+	builder.WriteString("func TestGeneratedGRPCStatus(t *testing.T) {\n")
+	builder.WriteString("\ttests := []struct {\n")
+	builder.WriteString("\t\tname    string\n")
+	builder.WriteString("\t\tfactory func(...error) *rescode.RC\n")
+	builder.WriteString("\t\tcode    codes.Code\n")
+	builder.WriteString("\t\tmessage string\n")
+	builder.WriteString("\t}{\n")
+	for _, errDef := range config.Errors {
+		builder.WriteString(fmt.Sprintf("\t\t{%q, %s, %sGRPC, %sMsg},\n", errDef.Key, errDef.Key, errDef.Key, errDef.Key))
+	}
+	builder.WriteString("\t}\n\n")
+	builder.WriteString("\tfor _, tt := range tests {\n")
+	builder.WriteString("\t\tt.Run(tt.name, func(t *testing.T) {\n")
+	builder.WriteString("\t\t\tst := tt.factory().GRPCStatus()\n")
+	builder.WriteString("\t\t\tif st.Code() != tt.code {\n")
+	builder.WriteString("\t\t\t\tt.Errorf(\"Expected gRPC code %v, got %v\", tt.code, st.Code())\n")
+	builder.WriteString("\t\t\t}\n")
+	builder.WriteString("\t\t\tif st.Message() != tt.message {\n")
+	builder.WriteString("\t\t\t\tt.Errorf(\"Expected gRPC message %q, got %q\", tt.message, st.Message())\n")
+	builder.WriteString("\t\t\t}\n")
+	builder.WriteString("\t\t})\n")
+	builder.WriteString("\t}\n")
+	builder.WriteString("}\n")
+
+	formatted, err := format.Source([]byte(builder.String()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated test: %w", err)
+	}
+
+	return formatted, nil
+}
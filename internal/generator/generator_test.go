@@ -1,6 +1,10 @@
 package generator
 
 import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -128,6 +132,55 @@ func TestParseInput_AutoDetect_YAML(t *testing.T) {
 	}
 }
 
+func TestParseInputBytes(t *testing.T) {
+	jsonInput := []byte(`[{"code": 20001, "key": "Test", "message": "Test message", "http": 400, "grpc": 3}]`)
+	yamlInput := []byte("- code: 20001\n  key: Test\n  message: Test message\n  http: 400\n  grpc: 3\n")
+
+	tests := []struct {
+		name string
+		data []byte
+		hint string
+	}{
+		{name: "json extension", data: jsonInput, hint: ".json"},
+		{name: "yaml format name", data: yamlInput, hint: "yaml"},
+		{name: "filename", data: yamlInput, hint: "errors.yml"},
+		{name: "auto-detect json", data: jsonInput, hint: ""},
+		{name: "auto-detect yaml", data: yamlInput, hint: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errors, err := ParseInputBytes(tt.data, tt.hint)
+			if err != nil {
+				t.Fatalf("Failed to parse input: %v", err)
+			}
+			if len(errors) != 1 || errors[0].Key != "Test" {
+				t.Errorf("Expected single Test definition, got %v", errors)
+			}
+		})
+	}
+}
+
+func TestParseInputFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "errors.yaml")
+	yamlInput := "- code: 20001\n  key: Test\n  message: Test message\n  http: 400\n  grpc: 3\n"
+	if err := os.WriteFile(path, []byte(yamlInput), 0644); err != nil {
+		t.Fatalf("Failed to write input file: %v", err)
+	}
+
+	errors, err := ParseInputFile(path)
+	if err != nil {
+		t.Fatalf("Failed to parse input file: %v", err)
+	}
+	if len(errors) != 1 || errors[0].Code != 20001 {
+		t.Errorf("Expected single definition with code 20001, got %v", errors)
+	}
+
+	if _, err := ParseInputFile(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("Expected error for missing input file")
+	}
+}
+
 func TestParseInput_Validation(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -327,6 +380,1384 @@ func TestGenerate_NoDescription(t *testing.T) {
 	}
 }
 
+func TestGenerate_CountGuard(t *testing.T) {
+	config := Config{
+		Package: "testpkg",
+		Errors: []ErrorDefinition{
+			{Code: 20001, Key: "PolicyNotFound", Message: "Policy not found", HTTP: 404, GRPC: 5},
+			{Code: 20002, Key: "InvalidKind", Message: "Invalid policy kind", HTTP: 400, GRPC: 3},
+		},
+		CountGuard: true,
+	}
+
+	code, err := Generate(config)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	codeStr := string(code)
+	expected := []string{
+		"const ErrorCount = 2",
+		"var _ = [ErrorCount]rescode.RcCreator{\n\tPolicyNotFound,\n\tInvalidKind,\n}",
+	}
+	for _, exp := range expected {
+		if !strings.Contains(codeStr, exp) {
+			t.Errorf("Generated code should contain %q, got:\n%s", exp, codeStr)
+		}
+	}
+}
+
+func TestGenerate_Keys(t *testing.T) {
+	config := Config{
+		Package: "testpkg",
+		Errors: []ErrorDefinition{
+			{Code: 20001, Key: "PolicyNotFound", Message: "Policy not found", HTTP: 404, GRPC: 5},
+			{Code: 20002, Key: "InvalidKind", Message: "Invalid policy kind", HTTP: 400, GRPC: 3},
+			{Code: 20003, Key: "InternalError", Message: "Internal server error", HTTP: 500, GRPC: 13},
+		},
+		Keys: true,
+	}
+
+	code, err := Generate(config)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	expected := "var Keys = []string{\n\t\"InternalError\",\n\t\"InvalidKind\",\n\t\"PolicyNotFound\",\n}"
+	if !strings.Contains(string(code), expected) {
+		t.Errorf("Generated code should contain sorted keys %q, got:\n%s", expected, code)
+	}
+}
+
+func TestGenerate_SSE(t *testing.T) {
+	config := Config{
+		Package: "testpkg",
+		Errors: []ErrorDefinition{
+			{Code: 20001, Key: "PolicyNotFound", Message: "Policy not found", HTTP: 404, GRPC: 5},
+			{Code: 20002, Key: "InvalidKind", Message: "Invalid policy kind", HTTP: 400, GRPC: 3},
+		},
+		SSE: true,
+	}
+
+	code, err := Generate(config)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	codeStr := string(code)
+	expected := []string{
+		"\"encoding/json\"\n\t\"fmt\"\n\t\"net/http\"\n",
+		"func CatalogSSE(w http.ResponseWriter) {",
+		`w.Header().Set("Content-Type", "text/event-stream")`,
+		"catalog := []rescode.RcCreator{\n\t\tPolicyNotFound,\n\t\tInvalidKind,\n\t}",
+		`fmt.Fprintf(w, "data: %s\n\n", data)`,
+	}
+	for _, exp := range expected {
+		if !strings.Contains(codeStr, exp) {
+			t.Errorf("Generated code should contain %q, got:\n%s", exp, codeStr)
+		}
+	}
+}
+
+func TestGenerate_Groups(t *testing.T) {
+	config := Config{
+		Package: "testpkg",
+		Errors: []ErrorDefinition{
+			{Code: 10001, Key: "LoginFailed", Message: "Login failed", HTTP: 401, GRPC: 16, Group: "Auth"},
+			{Code: 10002, Key: "TokenExpired", Message: "Token expired", HTTP: 401, GRPC: 16, Group: "Auth"},
+			{Code: 20001, Key: "PolicyNotFound", Message: "Policy not found", HTTP: 404, GRPC: 5},
+		},
+	}
+
+	code, err := Generate(config)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	codeStr := string(code)
+	expected := []string{
+		"// Auth groups the Auth errors.",
+		"var Auth = struct {\n\tLoginFailed  rescode.RcCreator\n\tTokenExpired rescode.RcCreator\n}{",
+		"LoginFailed:  LoginFailed,",
+		"TokenExpired: TokenExpired,",
+		"func PolicyNotFound(err ...error) *rescode.RC {",
+	}
+	for _, exp := range expected {
+		if !strings.Contains(codeStr, exp) {
+			t.Errorf("Generated code should contain %q, got:\n%s", exp, codeStr)
+		}
+	}
+	if strings.Contains(codeStr, "PolicyNotFound rescode.RcCreator") {
+		t.Error("Ungrouped definitions should not appear in a group accessor")
+	}
+}
+
+func TestGenerate_GroupCollision(t *testing.T) {
+	config := Config{Errors: []ErrorDefinition{
+		{Code: 1, Key: "Auth", Message: "Auth", HTTP: 401, GRPC: 16},
+		{Code: 2, Key: "LoginFailed", Message: "Login failed", HTTP: 401, GRPC: 16, Group: "Auth"},
+	}}
+
+	_, err := Generate(config)
+	if err == nil || !strings.Contains(err.Error(), "collides with group Auth") {
+		t.Errorf("Expected group collision error, got %v", err)
+	}
+}
+
+func TestGenerate_GroupReservedCollision(t *testing.T) {
+	config := Config{
+		Errors: []ErrorDefinition{
+			{Code: 1, Key: "LoginFailed", Message: "Login failed", HTTP: 401, GRPC: 16, Group: "Keys"},
+		},
+		Keys: true,
+	}
+
+	_, err := Generate(config)
+	if err == nil || !strings.Contains(err.Error(), "group Keys collides with the Keys slice") {
+		t.Errorf("Expected reserved name collision error, got %v", err)
+	}
+}
+
+func TestGenerate_SymbolCollision(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr string
+	}{
+		{
+			name: "Foo and FooMsg",
+			config: Config{Errors: []ErrorDefinition{
+				{Code: 1, Key: "Foo", Message: "Foo", HTTP: 400, GRPC: 3},
+				{Code: 2, Key: "FooMsg", Message: "Foo message", HTTP: 400, GRPC: 3},
+			}},
+			wantErr: "generated symbol FooMsg from key FooMsg collides with key Foo",
+		},
+		{
+			name: "FooMsg before Foo",
+			config: Config{Errors: []ErrorDefinition{
+				{Code: 2, Key: "FooMsg", Message: "Foo message", HTTP: 400, GRPC: 3},
+				{Code: 1, Key: "Foo", Message: "Foo", HTTP: 400, GRPC: 3},
+			}},
+			wantErr: "generated symbol FooMsg from key Foo collides with key FooMsg",
+		},
+		{
+			name: "duplicate key",
+			config: Config{Errors: []ErrorDefinition{
+				{Code: 1, Key: "Foo", Message: "Foo", HTTP: 400, GRPC: 3},
+				{Code: 2, Key: "Foo", Message: "Foo again", HTTP: 400, GRPC: 3},
+			}},
+			wantErr: "duplicate key Foo",
+		},
+		{
+			name: "sentinel collides with key",
+			config: Config{Sentinels: true, Errors: []ErrorDefinition{
+				{Code: 1, Key: "Foo", Message: "Foo", HTTP: 400, GRPC: 3},
+				{Code: 2, Key: "ErrFoo", Message: "Err foo", HTTP: 400, GRPC: 3},
+			}},
+			wantErr: "generated symbol ErrFoo from key ErrFoo collides with key Foo",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Generate(tt.config)
+			if err == nil {
+				t.Fatalf("Expected error containing %q, got nil", tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %q", tt.wantErr, err.Error())
+			}
+		})
+	}
+}
+
+func TestGenerateToWriter(t *testing.T) {
+	config := Config{
+		Package: "testpkg",
+		Errors: []ErrorDefinition{
+			{Code: 20001, Key: "PolicyNotFound", Message: "Policy not found", HTTP: 404, GRPC: 5},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := GenerateToWriter(config, &buf); err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	expected, err := Generate(config)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), expected) {
+		t.Errorf("GenerateToWriter output differs from Generate:\n%s\nvs\n%s", buf.String(), expected)
+	}
+}
+
+func TestGenerateToWriter_InvalidConfig(t *testing.T) {
+	config := Config{
+		Package: "invalid package",
+		Errors: []ErrorDefinition{
+			{Code: 20001, Key: "PolicyNotFound", Message: "Policy not found", HTTP: 404, GRPC: 5},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := GenerateToWriter(config, &buf); err == nil {
+		t.Error("Expected error for invalid package name")
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Expected nothing to be written on failure, got %d bytes", buf.Len())
+	}
+}
+
+func TestGenerate_Sentinels(t *testing.T) {
+	config := Config{
+		Package: "testpkg",
+		Errors: []ErrorDefinition{
+			{Code: 20001, Key: "PolicyNotFound", Message: "Policy not found", HTTP: 404, GRPC: 5},
+			{Code: 20002, Key: "InvalidKind", Message: "Invalid policy kind", HTTP: 400, GRPC: 3},
+		},
+	}
+
+	code, err := Generate(config)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+	if strings.Contains(string(code), "ErrPolicyNotFound") {
+		t.Error("Generated code should not contain sentinels by default")
+	}
+
+	config.Sentinels = true
+	code, err = Generate(config)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	codeStr := string(code)
+	for _, expected := range []string{"ErrPolicyNotFound = PolicyNotFound()", "ErrInvalidKind    = InvalidKind()"} {
+		if !strings.Contains(codeStr, expected) {
+			t.Errorf("Generated code should contain sentinel: %s", expected)
+		}
+	}
+}
+
+func TestGenerate_CodeEnum(t *testing.T) {
+	config := Config{
+		Package: "testpkg",
+		Errors: []ErrorDefinition{
+			{Code: 20001, Key: "PolicyNotFound", Message: "Policy not found", HTTP: 404, GRPC: 5},
+			{Code: 20002, Key: "InvalidKind", Message: "Invalid policy kind", HTTP: 400, GRPC: 3},
+		},
+		CodeEnum: true,
+	}
+
+	code, err := Generate(config)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	codeStr := string(code)
+	expected := []string{
+		`"strconv"`,
+		"type Code uint64",
+		"CodePolicyNotFound Code = Code(PolicyNotFoundCode)",
+		"CodeInvalidKind    Code = Code(InvalidKindCode)",
+		"func AllCodes() []Code {",
+		"\t\tCodePolicyNotFound,\n\t\tCodeInvalidKind,\n",
+		"func (c Code) String() string {",
+		"case CodePolicyNotFound:\n\t\treturn \"PolicyNotFound\"",
+	}
+	for _, exp := range expected {
+		if !strings.Contains(codeStr, exp) {
+			t.Errorf("Generated code should contain %q", exp)
+		}
+	}
+}
+
+func TestGenerate_CodeEnumDuplicateCode(t *testing.T) {
+	config := Config{
+		Package: "testpkg",
+		Errors: []ErrorDefinition{
+			{Code: 20001, Key: "PolicyNotFound", Message: "Policy not found", HTTP: 404, GRPC: 5},
+			{Code: 20001, Key: "InvalidKind", Message: "Invalid policy kind", HTTP: 400, GRPC: 3},
+		},
+		CodeEnum: true,
+	}
+
+	_, err := Generate(config)
+	if err == nil || !strings.Contains(err.Error(), "code 20001 of key InvalidKind is already used by key PolicyNotFound") {
+		t.Errorf("Expected duplicate code error, got %v", err)
+	}
+}
+
+func TestGenerate_Must(t *testing.T) {
+	config := Config{
+		Package: "testpkg",
+		Errors: []ErrorDefinition{
+			{Code: 20001, Key: "PolicyNotFound", Message: "Policy not found", HTTP: 404, GRPC: 5},
+		},
+		Must: true,
+	}
+
+	code, err := Generate(config)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	codeStr := string(code)
+	expected := []string{
+		"func MustPolicyNotFound(err error) *rescode.RC {",
+		"if err == nil {",
+		`panic("rescode: MustPolicyNotFound called with nil error")`,
+		"return PolicyNotFound(err)",
+	}
+	for _, exp := range expected {
+		if !strings.Contains(codeStr, exp) {
+			t.Errorf("Generated code should contain %q", exp)
+		}
+	}
+}
+
+func TestGenerate_Responses(t *testing.T) {
+	config := Config{
+		Package: "testpkg",
+		Errors: []ErrorDefinition{
+			{Code: 20001, Key: "PolicyNotFound", Message: "Policy not found", HTTP: 404, GRPC: 5},
+		},
+	}
+
+	code, err := Generate(config)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+	if strings.Contains(string(code), "PolicyNotFoundResponse") {
+		t.Error("Response constructors should not be emitted by default")
+	}
+
+	config.Responses = true
+	code, err = Generate(config)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	codeStr := string(code)
+	expected := []string{
+		"func PolicyNotFoundResponse() rescode.Response {",
+		"return PolicyNotFound().Response()",
+	}
+	for _, exp := range expected {
+		if !strings.Contains(codeStr, exp) {
+			t.Errorf("Generated code should contain %q", exp)
+		}
+	}
+}
+
+func TestGenerate_Header(t *testing.T) {
+	config := Config{
+		Package: "testpkg",
+		Errors: []ErrorDefinition{
+			{Code: 20001, Key: "PolicyNotFound", Message: "Policy not found", HTTP: 404, GRPC: 5},
+		},
+	}
+
+	code, err := Generate(config)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+	if !strings.HasPrefix(string(code), "// Code generated by rescodegen. DO NOT EDIT.\n") {
+		t.Errorf("Generated code should start with the DO NOT EDIT marker, got %q", strings.SplitN(string(code), "\n", 2)[0])
+	}
+
+	config.Header = "Copyright 2026 Example Corp.\n\n// SPDX-License-Identifier: MIT\n"
+	code, err = Generate(config)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	expected := "// Copyright 2026 Example Corp.\n//\n// SPDX-License-Identifier: MIT\n\n// Code generated by rescodegen. DO NOT EDIT.\n\npackage testpkg\n"
+	if !strings.HasPrefix(string(code), expected) {
+		t.Errorf("Expected header to be prepended, got:\n%s", string(code)[:len(expected)])
+	}
+
+	test, err := GenerateGRPCTest(config)
+	if err != nil {
+		t.Fatalf("Failed to generate gRPC test: %v", err)
+	}
+	if !strings.HasPrefix(string(test), expected) {
+		t.Error("Expected header to be prepended to the gRPC test file")
+	}
+}
+
+func TestGenerate_Validation(t *testing.T) {
+	yamlContent := `- code: 20001
+  key: InvalidEmail
+  message: Invalid email address
+  http: 400
+  grpc: 3
+  fields: [email, contact_email]
+- code: 20002
+  key: InvalidRequest
+  message: Invalid request
+  http: 400
+  grpc: 3
+  fields: ["*"]
+- code: 20003
+  key: PolicyNotFound
+  message: Policy not found
+  http: 404
+  grpc: 5`
+
+	errors, err := ParseInput(strings.NewReader(yamlContent), "test.yaml")
+	if err != nil {
+		t.Fatalf("Failed to parse input: %v", err)
+	}
+
+	code, err := Generate(Config{Package: "testpkg", Errors: errors, Validation: true})
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	codeStr := string(code)
+	expected := []string{
+		"func ValidationError(field string) *rescode.RC {",
+		`case "email", "contact_email":`,
+		"return InvalidEmail()",
+		"return InvalidRequest()\n}",
+	}
+	for _, exp := range expected {
+		if !strings.Contains(codeStr, exp) {
+			t.Errorf("Generated code should contain %q", exp)
+		}
+	}
+	if strings.Contains(codeStr, `case "*"`) {
+		t.Error("The fallback entry should not be emitted as a case")
+	}
+
+	// Without a "*" entry, unmapped fields return nil
+	code, err = Generate(Config{Package: "testpkg", Errors: errors[:1], Validation: true})
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+	if !strings.Contains(string(code), "return nil\n}") {
+		t.Error("Expected unmapped fields to return nil without a fallback entry")
+	}
+}
+
+func TestParseInput_DuplicateField(t *testing.T) {
+	yamlContent := `- code: 20001
+  key: InvalidEmail
+  message: Invalid email address
+  http: 400
+  fields: [email]
+- code: 20002
+  key: InvalidRequest
+  message: Invalid request
+  http: 400
+  fields: [email]`
+
+	_, err := ParseInput(strings.NewReader(yamlContent), "test.yaml")
+	if err == nil || !strings.Contains(err.Error(), `field "email" is already mapped to InvalidEmail`) {
+		t.Errorf("Expected duplicate field error, got %v", err)
+	}
+}
+
+func TestGenerate_ImportPath(t *testing.T) {
+	errors := []ErrorDefinition{
+		{Code: 20001, Key: "PolicyNotFound", Message: "Policy not found", HTTP: 404, GRPC: 5},
+	}
+
+	tests := []struct {
+		importPath string
+		expected   string
+	}{
+		{"", "\t\"github.com/restayway/rescode\"\n"},
+		{"example.com/internal/third_party/rescode", "\t\"example.com/internal/third_party/rescode\"\n"},
+		{"example.com/forks/rescodefork", "\trescode \"example.com/forks/rescodefork\"\n"},
+	}
+
+	for _, tt := range tests {
+		code, err := Generate(Config{Package: "testpkg", Errors: errors, ImportPath: tt.importPath})
+		if err != nil {
+			t.Fatalf("Failed to generate code: %v", err)
+		}
+		codeStr := string(code)
+		if !strings.Contains(codeStr, tt.expected) {
+			t.Errorf("Generated code should import %q, got:\n%s", tt.expected, codeStr)
+		}
+		if tt.importPath != "" && strings.Contains(codeStr, DefaultImportPath) {
+			t.Errorf("Generated code should not import %s with import path %s", DefaultImportPath, tt.importPath)
+		}
+	}
+}
+
+func TestGenerate_CodeType(t *testing.T) {
+	config := Config{
+		Package: "testpkg",
+		Errors: []ErrorDefinition{
+			{Code: 20001, Key: "PolicyNotFound", Message: "Policy not found", HTTP: 404, GRPC: 5},
+		},
+		CodeEnum: true,
+		CodeType: "uint32",
+	}
+
+	code, err := Generate(config)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	codeStr := string(code)
+	expected := []string{
+		"PolicyNotFoundCode uint32",
+		"return rescode.New(uint64(PolicyNotFoundCode), PolicyNotFoundHTTP, PolicyNotFoundGRPC, PolicyNotFoundMsg)(err...)",
+		"type Code uint32",
+	}
+	for _, exp := range expected {
+		if !strings.Contains(codeStr, exp) {
+			t.Errorf("Generated code should contain %q", exp)
+		}
+	}
+
+	config.Errors = append(config.Errors, ErrorDefinition{Code: 1 << 32, Key: "TooBig", Message: "Too big", HTTP: 500, GRPC: 13})
+	_, err = Generate(config)
+	if err == nil || !strings.Contains(err.Error(), "code 4294967296 of key TooBig does not fit in uint32") {
+		t.Errorf("Expected out-of-range code error, got %v", err)
+	}
+
+	config.CodeType = "int32"
+	if _, err := Generate(config); err == nil {
+		t.Error("Expected an error for an unsupported code type")
+	}
+}
+
+func TestGenerate_Suggestion(t *testing.T) {
+	yamlContent := `- code: 20001
+  key: PolicyNotFound
+  message: Policy not found
+  http: 404
+  grpc: 5
+  suggestion: Check the policy ID and try again
+- code: 20002
+  key: InvalidKind
+  message: Invalid policy kind
+  http: 400
+  grpc: 3`
+
+	errors, err := ParseInput(strings.NewReader(yamlContent), "test.yaml")
+	if err != nil {
+		t.Fatalf("Failed to parse input: %v", err)
+	}
+	if errors[0].Suggestion != "Check the policy ID and try again" {
+		t.Errorf("Expected suggestion to be parsed, got %q", errors[0].Suggestion)
+	}
+
+	code, err := Generate(Config{Package: "testpkg", Errors: errors})
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	codeStr := string(code)
+	expected := []string{
+		"PolicyNotFoundSuggestion string",
+		`"Check the policy ID and try again"`,
+		"PolicyNotFoundMsg)(err...).WithSuggestion(PolicyNotFoundSuggestion)",
+		"InvalidKindMsg)(err...)\n",
+	}
+	for _, exp := range expected {
+		if !strings.Contains(codeStr, exp) {
+			t.Errorf("Generated code should contain %q", exp)
+		}
+	}
+	if strings.Contains(codeStr, "InvalidKindSuggestion") {
+		t.Error("No suggestion constant should be emitted without a suggestion")
+	}
+}
+
+func TestGenerate_MultiLineDesc(t *testing.T) {
+	yamlContent := `- code: 20001
+  key: PolicyNotFound
+  message: Policy not found
+  http: 404
+  desc: |
+    Policy could not be located in the database.
+    Check that the ID belongs to the caller's tenant.
+
+    Example:
+      GET /policies/p1`
+
+	errors, err := ParseInput(strings.NewReader(yamlContent), "test.yaml")
+	if err != nil {
+		t.Fatalf("Failed to parse input: %v", err)
+	}
+
+	code, err := Generate(Config{Package: "testpkg", Errors: errors})
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	// gofmt turns the indented line into a code block
+	expected := "// PolicyNotFound creates a new PolicyNotFound error.\n" +
+		"// Policy could not be located in the database.\n" +
+		"// Check that the ID belongs to the caller's tenant.\n" +
+		"//\n" +
+		"// Example:\n" +
+		"//\n" +
+		"//\tGET /policies/p1\n" +
+		"func PolicyNotFound("
+	if !strings.Contains(string(code), expected) {
+		t.Errorf("Generated code should contain %q, got:\n%s", expected, code)
+	}
+}
+
+func TestGenerate_Deprecated(t *testing.T) {
+	yamlContent := `- code: 20001
+  key: PolicyNotFound
+  message: Policy not found
+  http: 404
+  desc: Policy could not be located
+  deprecated: use PolicyMissing instead.
+- code: 20002
+  key: PolicyMissing
+  message: Policy missing
+  http: 404
+- code: 20003
+  key: OldKind
+  message: Old kind
+  http: 400
+  deprecated: true
+  replaced_by: PolicyMissing
+- code: 20004
+  key: Legacy
+  message: Legacy
+  http: 400
+  deprecated: true`
+
+	errors, err := ParseInput(strings.NewReader(yamlContent), "test.yaml")
+	if err != nil {
+		t.Fatalf("Failed to parse input: %v", err)
+	}
+
+	code, err := Generate(Config{Package: "testpkg", Errors: errors})
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	codeStr := string(code)
+	expected := []string{
+		"// Policy could not be located\n//\n// Deprecated: use PolicyMissing instead.\nfunc PolicyNotFound(",
+		"\t// Deprecated: use PolicyMissing instead.\n\tPolicyNotFoundCode uint64 = 20001\n",
+		"\t// Deprecated: use PolicyMissing instead.\n\tPolicyNotFoundMsg string = \"Policy not found\"\n",
+		"\t// Deprecated: use PolicyMissing instead.\n\tPolicyNotFoundDesc string",
+		"// Deprecated: use PolicyMissing instead.\nfunc OldKind(",
+		"// Deprecated: Legacy is kept for compatibility only.\nfunc Legacy(",
+	}
+	for _, exp := range expected {
+		if !strings.Contains(codeStr, exp) {
+			t.Errorf("Generated code should contain %q", exp)
+		}
+	}
+	// Every constant and the factory of each deprecated error; PolicyNotFound also has a Desc
+	if n := strings.Count(codeStr, "// Deprecated:"); n != 6+5+5 {
+		t.Errorf("Expected 16 Deprecated comments, got %d", n)
+	}
+	if strings.Contains(codeStr, "// Deprecated:\n\tPolicyMissingCode") {
+		t.Error("PolicyMissing should not be marked deprecated")
+	}
+}
+
+func TestGenerate_Canonical(t *testing.T) {
+	yamlContent := `- code: 20001
+  key: PolicyNotFound
+  message: Policy not found
+  http: 404
+  grpc: 5
+  deprecated: true
+  replaced_by: PolicyMissing
+- code: 20002
+  key: PolicyMissing
+  message: Policy missing
+  http: 404
+  grpc: 5
+  replaced_by: PolicyGone
+- code: 20003
+  key: PolicyGone
+  message: Policy gone
+  http: 404
+  grpc: 5`
+
+	errors, err := ParseInput(strings.NewReader(yamlContent), "test.yaml")
+	if err != nil {
+		t.Fatalf("Failed to parse input: %v", err)
+	}
+	if errors[0].ReplacedBy != "PolicyMissing" {
+		t.Errorf("Expected replaced_by to be parsed, got %q", errors[0].ReplacedBy)
+	}
+
+	code, err := Generate(Config{Package: "testpkg", Errors: errors})
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	codeStr := string(code)
+	expected := []string{
+		"func Canonical(code uint64) uint64 {",
+		"case PolicyNotFoundCode:\n\t\treturn PolicyGoneCode",
+		"case PolicyMissingCode:\n\t\treturn PolicyGoneCode",
+		"return code\n}",
+	}
+	for _, exp := range expected {
+		if !strings.Contains(codeStr, exp) {
+			t.Errorf("Generated code should contain %q", exp)
+		}
+	}
+
+	code, err = Generate(Config{Package: "testpkg", Errors: errors[2:]})
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+	if strings.Contains(string(code), "Canonical") {
+		t.Error("Canonical should not be emitted without replaced_by entries")
+	}
+}
+
+func TestParseInput_InvalidReplacedBy(t *testing.T) {
+	tests := []struct {
+		name     string
+		yaml     string
+		expected string
+	}{
+		{
+			name: "unknown key",
+			yaml: `- code: 1
+  key: Old
+  message: Old
+  http: 404
+  replaced_by: Missing`,
+			expected: `replaced_by "Missing" is not a defined key`,
+		},
+		{
+			name: "cycle",
+			yaml: `- code: 1
+  key: A
+  message: A
+  http: 404
+  replaced_by: B
+- code: 2
+  key: B
+  message: B
+  http: 404
+  replaced_by: A`,
+			expected: "loops back to A",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseInput(strings.NewReader(tt.yaml), "test.yaml")
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("Expected error containing %q, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestParseInput_UnknownField(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		input    string
+		expected string
+	}{
+		{
+			name:     "YAML",
+			filename: "test.yaml",
+			input: `- code: 20001
+  key: PolicyNotFound
+  message: Policy not found
+  http: 404
+- code: 20002
+  key: InvalidKind
+  message: Invalid policy kind
+  htpt: 400`,
+			expected: `error definition 1: unknown field "htpt" (line 8)`,
+		},
+		{
+			name:     "JSON",
+			filename: "test.json",
+			input:    `[{"code": 20001, "key": "PolicyNotFound", "message": "Policy not found", "htpt": 404}]`,
+			expected: `error definition 0: unknown field "htpt"`,
+		},
+		{
+			name:     "auto-detected JSON",
+			filename: "",
+			input:    `[{"code": 20001, "key": "PolicyNotFound", "message": "Policy not found", "http": 404, "ownr": "me"}]`,
+			expected: `error definition 0: unknown field "ownr"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseInput(strings.NewReader(tt.input), tt.filename)
+			if err == nil || err.Error() != tt.expected {
+				t.Errorf("Expected error %q, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestParseInput_JSONFieldCase(t *testing.T) {
+	input := `[{"Code": 20001, "Key": "PolicyNotFound", "Message": "Policy not found", "HTTP": 404}]`
+
+	if _, err := ParseInput(strings.NewReader(input), "test.json"); err != nil {
+		t.Errorf("Expected case-insensitive JSON field names to be accepted, got %v", err)
+	}
+}
+
+func TestGenerate_KeyForCode(t *testing.T) {
+	config := Config{
+		Package: "testpkg",
+		Errors: []ErrorDefinition{
+			{Code: 20001, Key: "PolicyNotFound", Message: "Policy not found", HTTP: 404, GRPC: 5},
+			{Code: 20002, Key: "InvalidKind", Message: "Invalid policy kind", HTTP: 400, GRPC: 3},
+		},
+		KeyForCode: true,
+		CodeEnum:   true,
+	}
+
+	code, err := Generate(config)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	codeStr := string(code)
+	expected := []string{
+		"func KeyForCode(code uint64) string {",
+		"case PolicyNotFoundCode:\n\t\treturn \"PolicyNotFound\"",
+		"case InvalidKindCode:\n\t\treturn \"InvalidKind\"",
+		`return "UNKNOWN(" + strconv.FormatUint(code, 10) + ")"`,
+	}
+	for _, exp := range expected {
+		if !strings.Contains(codeStr, exp) {
+			t.Errorf("Generated code should contain %q", exp)
+		}
+	}
+	if strings.Count(codeStr, `"strconv"`) != 1 {
+		t.Error("strconv should be imported exactly once")
+	}
+}
+
+func TestGenerate_KeyForCodeDuplicateCode(t *testing.T) {
+	config := Config{
+		Package: "testpkg",
+		Errors: []ErrorDefinition{
+			{Code: 20001, Key: "PolicyNotFound", Message: "Policy not found", HTTP: 404, GRPC: 5},
+			{Code: 20001, Key: "InvalidKind", Message: "Invalid policy kind", HTTP: 400, GRPC: 3},
+		},
+		KeyForCode: true,
+	}
+
+	_, err := Generate(config)
+	if err == nil || !strings.Contains(err.Error(), "code 20001 of key InvalidKind is already used by key PolicyNotFound") {
+		t.Errorf("Expected duplicate code error, got %v", err)
+	}
+}
+
+func TestGenerate_CLI(t *testing.T) {
+	config := Config{
+		Package: "testpkg",
+		Errors: []ErrorDefinition{
+			{Code: 20001, Key: "PolicyNotFound", Message: "Policy not found", HTTP: 404, GRPC: 5},
+			{Code: 20002, Key: "InvalidKind", Message: "Invalid policy kind", HTTP: 400, GRPC: 3},
+		},
+		CLI: true,
+	}
+
+	code, err := Generate(config)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	codeStr := string(code)
+	expected := []string{
+		`"text/tabwriter"`,
+		"func ErrorsCommand(args []string, w io.Writer) error {",
+		`{"PolicyNotFound", PolicyNotFound},`,
+		`{"InvalidKind", InvalidKind},`,
+		`fmt.Fprintln(tw, "CODE\tKEY\tMESSAGE")`,
+		"return tw.Flush()",
+	}
+	for _, exp := range expected {
+		if !strings.Contains(codeStr, exp) {
+			t.Errorf("Generated code should contain %q", exp)
+		}
+	}
+}
+
+func TestGenerate_Decoder(t *testing.T) {
+	config := Config{
+		Package: "testpkg",
+		Errors: []ErrorDefinition{
+			{Code: 20001, Key: "PolicyNotFound", Message: "Policy not found", HTTP: 404, GRPC: 5},
+			{Code: 20002, Key: "InvalidKind", Message: "Invalid policy kind", HTTP: 400, GRPC: 3},
+		},
+		Decoder: true,
+	}
+
+	code, err := Generate(config)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	codeStr := string(code)
+	expected := []string{
+		"func DecodeError(body []byte) (*rescode.RC, error) {",
+		"if err := json.Unmarshal(body, &payload); err != nil {",
+		"case PolicyNotFoundCode:\n\t\trc = PolicyNotFound()",
+		"case InvalidKindCode:\n\t\trc = InvalidKind()",
+		"rc = rescode.New(payload.Code, payload.HTTPCode, codes.Code(payload.RPCCode), payload.Message)()",
+		"rc.SetData(payload.Data)",
+	}
+	for _, exp := range expected {
+		if !strings.Contains(codeStr, exp) {
+			t.Errorf("Generated code should contain %q", exp)
+		}
+	}
+}
+
+func TestGenerate_DecoderDuplicateCode(t *testing.T) {
+	config := Config{
+		Package: "testpkg",
+		Errors: []ErrorDefinition{
+			{Code: 20001, Key: "PolicyNotFound", Message: "Policy not found", HTTP: 404, GRPC: 5},
+			{Code: 20001, Key: "InvalidKind", Message: "Invalid policy kind", HTTP: 400, GRPC: 3},
+		},
+		Decoder: true,
+	}
+
+	_, err := Generate(config)
+	if err == nil || !strings.Contains(err.Error(), "code 20001 of key InvalidKind is already used by key PolicyNotFound") {
+		t.Errorf("Expected duplicate code error, got %v", err)
+	}
+}
+
+func TestGenerate_TypedConstants(t *testing.T) {
+	config := Config{
+		Package: "testpkg",
+		Errors: []ErrorDefinition{
+			{Code: 20001, Key: "PolicyNotFound", Message: "Policy not found", HTTP: 404, GRPC: 5},
+		},
+		TypedConstants: true,
+	}
+
+	code, err := Generate(config)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	codeStr := string(code)
+	expected := []string{
+		"type HTTPStatus int",
+		"PolicyNotFoundHTTP HTTPStatus = 404",
+		"rescode.New(PolicyNotFoundCode, int(PolicyNotFoundHTTP), PolicyNotFoundGRPC, PolicyNotFoundMsg)",
+	}
+	for _, exp := range expected {
+		if !strings.Contains(codeStr, exp) {
+			t.Errorf("Generated code should contain %q", exp)
+		}
+	}
+
+	config.TypedConstants = false
+	code, err = Generate(config)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+	if strings.Contains(string(code), "HTTPStatus") {
+		t.Error("Generated code should not declare HTTPStatus without TypedConstants")
+	}
+}
+
+func TestGenerate_TypedConstantsCollision(t *testing.T) {
+	config := Config{
+		Package: "testpkg",
+		Errors: []ErrorDefinition{
+			{Code: 20001, Key: "HTTPStatus", Message: "Status", HTTP: 404, GRPC: 5},
+		},
+		TypedConstants: true,
+	}
+
+	if _, err := Generate(config); err == nil || !strings.Contains(err.Error(), "collides") {
+		t.Errorf("Expected a collision error, got %v", err)
+	}
+}
+
+func TestGenerateSplit(t *testing.T) {
+	config := Config{
+		Package: "testpkg",
+		Errors: []ErrorDefinition{
+			{Code: 10001, Key: "LoginFailed", Message: "Login failed", HTTP: 401, GRPC: 16, Group: "Auth"},
+			{Code: 20001, Key: "PolicyNotFound", Message: "Policy not found", HTTP: 404, GRPC: 5, Group: "Policy"},
+			{Code: 20002, Key: "InvalidKind", Message: "Invalid policy kind", HTTP: 400, GRPC: 3, Group: "Policy"},
+		},
+		Keys: true,
+	}
+
+	files, err := GenerateSplit(config)
+	if err != nil {
+		t.Fatalf("Failed to generate split code: %v", err)
+	}
+	if len(files) != 3 {
+		t.Fatalf("Expected files for Auth, Policy and the package-wide declarations, got %d", len(files))
+	}
+
+	tests := []struct {
+		group   string
+		present []string
+		absent  []string
+	}{
+		{
+			group:   "Auth",
+			present: []string{"package testpkg", "func LoginFailed(", "var Auth = struct {", "\"github.com/restayway/rescode\"", "\"google.golang.org/grpc/codes\""},
+			absent:  []string{"PolicyNotFound", "InvalidKind", "var Keys"},
+		},
+		{
+			group:   "Policy",
+			present: []string{"package testpkg", "func PolicyNotFound(", "func InvalidKind(", "var Policy = struct {", "\"github.com/restayway/rescode\""},
+			absent:  []string{"LoginFailed", "var Keys"},
+		},
+		{
+			group:   "",
+			present: []string{"package testpkg", "var Keys = []string{", "\"InvalidKind\"", "\"LoginFailed\""},
+			absent:  []string{"func LoginFailed(", "func PolicyNotFound(", "\"github.com/restayway/rescode\""},
+		},
+	}
+	for _, tt := range tests {
+		code := string(files[tt.group])
+		for _, exp := range tt.present {
+			if !strings.Contains(code, exp) {
+				t.Errorf("File for group %q should contain %q", tt.group, exp)
+			}
+		}
+		for _, exp := range tt.absent {
+			if strings.Contains(code, exp) {
+				t.Errorf("File for group %q should not contain %q", tt.group, exp)
+			}
+		}
+	}
+}
+
+func TestGenerateSplit_NoCatalogFile(t *testing.T) {
+	config := Config{
+		Package: "testpkg",
+		Errors: []ErrorDefinition{
+			{Code: 10001, Key: "LoginFailed", Message: "Login failed", HTTP: 401, GRPC: 16, Group: "Auth"},
+		},
+	}
+
+	files, err := GenerateSplit(config)
+	if err != nil {
+		t.Fatalf("Failed to generate split code: %v", err)
+	}
+	if _, exists := files[""]; exists {
+		t.Error("Expected no package-wide file when every error is grouped and no package-wide option is set")
+	}
+}
+
+func TestGroupByCategory(t *testing.T) {
+	errors := []ErrorDefinition{
+		{Code: 20001, Key: "PolicyNotFound", Category: "policy"},
+		{Code: 10001, Key: "LoginFailed", Category: "auth"},
+		{Code: 30001, Key: "Unknown"},
+		{Code: 20002, Key: "InvalidKind", Category: "policy"},
+	}
+
+	uncategorized, categories, grouped := GroupByCategory(errors)
+
+	if len(uncategorized) != 1 || uncategorized[0].Key != "Unknown" {
+		t.Errorf("Expected only Unknown to be uncategorized, got %v", uncategorized)
+	}
+	if strings.Join(categories, ",") != "auth,policy" {
+		t.Errorf("Expected sorted categories [auth policy], got %v", categories)
+	}
+	if len(grouped["policy"]) != 2 {
+		t.Errorf("Expected 2 policy definitions, got %d", len(grouped["policy"]))
+	}
+	if len(grouped["auth"]) != 1 {
+		t.Errorf("Expected 1 auth definition, got %d", len(grouped["auth"]))
+	}
+}
+
+func TestParseInput_InvalidCategory(t *testing.T) {
+	input := `[{"code": 20001, "key": "Test", "message": "Test message", "http": 400, "grpc": 3, "category": "not-valid"}]`
+
+	_, err := ParseInput(strings.NewReader(input), "test.json")
+	if err == nil || !strings.Contains(err.Error(), "not a valid Go identifier") {
+		t.Errorf("Expected invalid category error, got %v", err)
+	}
+}
+
+func TestParseCodeRange(t *testing.T) {
+	min, max, err := ParseCodeRange("20000-20999")
+	if err != nil {
+		t.Fatalf("Failed to parse code range: %v", err)
+	}
+	if min != 20000 || max != 20999 {
+		t.Errorf("Expected range 20000-20999, got %d-%d", min, max)
+	}
+
+	for _, input := range []string{"20000", "abc-20999", "20999-20000"} {
+		if _, _, err := ParseCodeRange(input); err == nil {
+			t.Errorf("Expected error for code range %q", input)
+		}
+	}
+}
+
+func TestParseInput_Whitespace(t *testing.T) {
+	tests := []struct {
+		name  string
+		yaml  string
+		match string
+	}{
+		{
+			name: "trailing newline message",
+			yaml: `- code: 20001
+  key: PolicyNotFound
+  message: |
+    Policy not found
+  http: 404
+  grpc: 5`,
+			match: `error definition 0: message "Policy not found\n" has leading or trailing whitespace`,
+		},
+		{
+			name: "padded key",
+			yaml: `- code: 20001
+  key: PolicyNotFound
+  message: Policy not found
+  http: 404
+  grpc: 5
+- code: 20002
+  key: " InvalidKind"
+  message: Invalid kind
+  http: 400
+  grpc: 3`,
+			match: `error definition 1: key " InvalidKind" has leading or trailing whitespace`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseInput(strings.NewReader(tt.yaml), "errors.yaml")
+			if err == nil || !strings.Contains(err.Error(), tt.match) {
+				t.Errorf("Expected error containing %q, got %v", tt.match, err)
+			}
+		})
+	}
+}
+
+func TestValidateMessageLength(t *testing.T) {
+	errors := []ErrorDefinition{
+		{Code: 20001, Key: "PolicyNotFound", Message: "Policy not found"},
+		{Code: 20002, Key: "Verbose", Message: strings.Repeat("é", 81)},
+	}
+
+	if err := ValidateMessageLength(errors[:1], 80); err != nil {
+		t.Errorf("Expected short messages to pass, got %v", err)
+	}
+
+	err := ValidateMessageLength(errors, 80)
+	if err == nil {
+		t.Fatal("Expected an over-long message to fail")
+	}
+	expected := "error definition 1: message of Verbose is 81 characters, longer than the maximum of 80"
+	if err.Error() != expected {
+		t.Errorf("Expected %q, got %q", expected, err.Error())
+	}
+}
+
+func TestValidateCodeRange(t *testing.T) {
+	errors := []ErrorDefinition{
+		{Code: 20001, Key: "PolicyNotFound"},
+		{Code: 10001, Key: "LoginFailed"},
+		{Code: 21000, Key: "Overflow"},
+	}
+
+	if err := ValidateCodeRange(errors[:1], 20000, 20999); err != nil {
+		t.Errorf("Expected in-range codes to pass, got %v", err)
+	}
+
+	err := ValidateCodeRange(errors, 20000, 20999)
+	if err == nil {
+		t.Fatal("Expected out-of-range codes to fail")
+	}
+	for _, expected := range []string{"LoginFailed (10001)", "Overflow (21000)"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected error to list %q, got %q", expected, err.Error())
+		}
+	}
+	if strings.Contains(err.Error(), "PolicyNotFound") {
+		t.Errorf("Expected error not to list in-range PolicyNotFound, got %q", err.Error())
+	}
+}
+
+func TestValidateNoOKCodes(t *testing.T) {
+	errors := []ErrorDefinition{
+		{Code: 20001, Key: "PolicyNotFound", GRPC: 5},
+		{Code: 20002, Key: "Accepted", GRPC: 0},
+	}
+
+	if err := ValidateNoOKCodes(errors[:1]); err != nil {
+		t.Errorf("Expected error codes to pass, got %v", err)
+	}
+
+	err := ValidateNoOKCodes(errors)
+	expected := "grpc code 0 (OK) is a success code, not an error: Accepted (20002)"
+	if err == nil || err.Error() != expected {
+		t.Errorf("Expected %q, got %v", expected, err)
+	}
+}
+
+func TestValidateCodePrefix(t *testing.T) {
+	errors := []ErrorDefinition{
+		{Code: 20001, Key: "PolicyNotFound"},
+		{Code: 10001, Key: "LoginFailed"},
+		{Code: 30002, Key: "QuotaExceeded"},
+	}
+
+	if err := ValidateCodePrefix(errors[:1], "2"); err != nil {
+		t.Errorf("Expected matching codes to pass, got %v", err)
+	}
+
+	err := ValidateCodePrefix(errors, "2")
+	if err == nil {
+		t.Fatal("Expected codes with another prefix to fail")
+	}
+	expected := "codes not starting with 2: LoginFailed (10001), QuotaExceeded (30002)"
+	if err.Error() != expected {
+		t.Errorf("Expected %q, got %q", expected, err.Error())
+	}
+}
+
+func TestValidateCodePattern(t *testing.T) {
+	errors := []ErrorDefinition{
+		{Code: 20001, Key: "PolicyNotFound"},
+		{Code: 2001, Key: "TooShort"},
+		{Code: 120001, Key: "TooLong"},
+	}
+
+	if err := ValidateCodePattern(errors[:1], `2\d{4}`); err != nil {
+		t.Errorf("Expected matching codes to pass, got %v", err)
+	}
+
+	err := ValidateCodePattern(errors, `2\d{4}`)
+	if err == nil {
+		t.Fatal("Expected non-matching codes to fail")
+	}
+	for _, expected := range []string{"TooShort (2001)", "TooLong (120001)"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected error to list %q, got %q", expected, err.Error())
+		}
+	}
+	if strings.Contains(err.Error(), "PolicyNotFound") {
+		t.Errorf("Expected error not to list matching PolicyNotFound, got %q", err.Error())
+	}
+
+	if err := ValidateCodePattern(errors, "2("); err == nil || !strings.Contains(err.Error(), "invalid code pattern") {
+		t.Errorf("Expected an invalid pattern error, got %v", err)
+	}
+}
+
+func TestGenerateFactoryTest(t *testing.T) {
+	config := Config{
+		Package: "testpkg",
+		Errors: []ErrorDefinition{
+			{Code: 20001, Key: "PolicyNotFound", Message: "Policy not found", HTTP: 404, GRPC: 5},
+			{Code: 20002, Key: "InvalidKind", Message: "Invalid policy kind", HTTP: 400, GRPC: 3},
+		},
+	}
+
+	code, err := GenerateFactoryTest(config)
+	if err != nil {
+		t.Fatalf("Failed to generate factory test: %v", err)
+	}
+
+	codeStr := string(code)
+	expected := []string{
+		"package testpkg",
+		"func TestGeneratedFactories(t *testing.T) {",
+		`{"PolicyNotFound", PolicyNotFound, 20001, 404, 5, "Policy not found"},`,
+		`{"InvalidKind", InvalidKind, 20002, 400, 3, "Invalid policy kind"},`,
+		"if rc.Code != tt.code {",
+		"if rc.Message != tt.message {",
+	}
+	for _, exp := range expected {
+		if !strings.Contains(codeStr, exp) {
+			t.Errorf("Generated test should contain %q", exp)
+		}
+	}
+}
+
+func TestGenerateGRPCRoundTripTest(t *testing.T) {
+	config := Config{
+		Package: "testpkg",
+		Errors: []ErrorDefinition{
+			{Code: 20001, Key: "PolicyNotFound", Message: "Policy not found", HTTP: 404, GRPC: 5},
+			{Code: 20002, Key: "InvalidKind", Message: "Invalid policy kind", HTTP: 400, GRPC: 3},
+			{Code: 20003, Key: "Internal", Message: "Internal error", HTTP: 500, GRPC: 13},
+			{Code: 20004, Key: "Accepted", Message: "Request accepted", HTTP: 200, GRPC: 0},
+		},
+	}
+
+	code, err := GenerateGRPCRoundTripTest(config)
+	if err != nil {
+		t.Fatalf("Failed to generate round-trip test: %v", err)
+	}
+
+	codeStr := string(code)
+	expected := []string{
+		"package testpkg",
+		"func TestGeneratedGRPCRoundTrip(t *testing.T) {",
+		"got := rescode.FromGRPCStatus(status.FromProto(rc.GRPCStatus().Proto()))",
+		"if got.Code != rc.Code {",
+		"if got.Message != rc.Message {",
+		"if rc.RpcCode == codes.OK {\n\t\t\t\tif got != nil {",
+	}
+	for _, errDef := range config.Errors {
+		expected = append(expected, fmt.Sprintf("{%q, %s},", errDef.Key, errDef.Key))
+	}
+	for _, exp := range expected {
+		if !strings.Contains(codeStr, exp) {
+			t.Errorf("Generated test should contain %q", exp)
+		}
+	}
+}
+
+func TestGenerateGRPCTest(t *testing.T) {
+	config := Config{
+		Package: "testpkg",
+		Errors: []ErrorDefinition{
+			{Code: 20001, Key: "PolicyNotFound", Message: "Policy not found", HTTP: 404, GRPC: 5},
+			{Code: 20002, Key: "InvalidKind", Message: "Invalid policy kind", HTTP: 400, GRPC: 3},
+		},
+	}
+
+	code, err := GenerateGRPCTest(config)
+	if err != nil {
+		t.Fatalf("Failed to generate gRPC test: %v", err)
+	}
+
+	codeStr := string(code)
+	expected := []string{
+		"package testpkg",
+		"func TestGeneratedGRPCStatus(t *testing.T) {",
+		`{"PolicyNotFound", PolicyNotFound, PolicyNotFoundGRPC, PolicyNotFoundMsg},`,
+		`{"InvalidKind", InvalidKind, InvalidKindGRPC, InvalidKindMsg},`,
+		"st := tt.factory().GRPCStatus()",
+	}
+	for _, exp := range expected {
+		if !strings.Contains(codeStr, exp) {
+			t.Errorf("Generated test should contain %q", exp)
+		}
+	}
+}
+
 // Benchmark tests
 func BenchmarkParseInput_YAML(b *testing.B) {
 	yamlInput := `
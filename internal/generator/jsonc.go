@@ -0,0 +1,59 @@
+package generator
+
+import "bytes"
+
+// stripJSONComments returns a copy of data with // and /* */ comments and
+// trailing commas before a closing ] or } blanked out with spaces, so the
+// result is plain JSON and offsets in later syntax errors still point into
+// the original input. Text inside strings is left untouched.
+func stripJSONComments(data []byte) []byte {
+	out := append([]byte(nil), data...)
+	inString := false
+	lastComma := -1
+
+	for i := 0; i < len(out); i++ {
+		c := out[i]
+		if inString {
+			switch c {
+			case '\\':
+				i++
+			case '"':
+				inString = false
+			}
+			continue
+		}
+
+		switch {
+		case c == '"':
+			inString = true
+			lastComma = -1
+		case c == '/' && i+1 < len(out) && out[i+1] == '/':
+			for ; i < len(out) && out[i] != '\n'; i++ {
+				out[i] = ' '
+			}
+		case c == '/' && i+1 < len(out) && out[i+1] == '*':
+			stop := len(out)
+			if end := bytes.Index(out[i+2:], []byte("*/")); end >= 0 {
+				stop = i + 2 + end + 2
+			}
+			for ; i < stop; i++ {
+				if out[i] != '\n' {
+					out[i] = ' '
+				}
+			}
+			i--
+		case c == ',':
+			lastComma = i
+		case c == ']' || c == '}':
+			if lastComma >= 0 {
+				out[lastComma] = ' '
+			}
+			lastComma = -1
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+		default:
+			lastComma = -1
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,77 @@
+package generator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseInput_CommentedJSON(t *testing.T) {
+	commented := `// Policy service errors
+[
+  {
+    "code": 20001, // looked up by ID
+    "key": "PolicyNotFound",
+    "message": "Policy not found // not a comment",
+    "http": 404,
+    "grpc": 5,
+    /* "desc": "disabled", */
+    "fields": ["id", "name",],
+  },
+  {
+    "code": 20002,
+    "key": "InvalidKind",
+    "message": "Invalid \"kind\" /* kept */",
+    "http": 400,
+    "grpc": 3,
+  },
+]
+`
+	plain := `[
+  {
+    "code": 20001,
+    "key": "PolicyNotFound",
+    "message": "Policy not found // not a comment",
+    "http": 404,
+    "grpc": 5,
+    "fields": ["id", "name"]
+  },
+  {
+    "code": 20002,
+    "key": "InvalidKind",
+    "message": "Invalid \"kind\" /* kept */",
+    "http": 400,
+    "grpc": 3
+  }
+]`
+
+	want, err := ParseInput(strings.NewReader(plain), "errors.json")
+	if err != nil {
+		t.Fatalf("Failed to parse plain JSON: %v", err)
+	}
+
+	for _, name := range []string{"errors.json", "errors.jsonc", "errors.json5"} {
+		got, err := ParseInput(strings.NewReader(commented), name)
+		if err != nil {
+			t.Fatalf("%s: failed to parse commented JSON: %v", name, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: expected %+v, got %+v", name, want, got)
+		}
+	}
+}
+
+func TestStripJSONComments_PreservesOffsets(t *testing.T) {
+	input := "[1, /* two */ 2,\n// three\n]"
+	got := stripJSONComments([]byte(input))
+
+	if len(got) != len(input) {
+		t.Fatalf("Expected length %d, got %d", len(input), len(got))
+	}
+	if strings.Count(string(got), "\n") != 2 {
+		t.Errorf("Expected newlines to be kept, got %q", got)
+	}
+	if strings.Join(strings.Fields(string(got)), "") != "[1,2]" {
+		t.Errorf("Expected comments and trailing comma removed, got %q", got)
+	}
+}
@@ -0,0 +1,77 @@
+package generator
+
+import "fmt"
+
+// httpToGRPC lists the gRPC codes consistent with each HTTP status, following
+// the grpc-gateway conventions. The first entry is the canonical code used
+// when correcting a mismatch or inferring an omitted grpc field:
+//
+//	200 OK, 400 InvalidArgument, 401 Unauthenticated, 403 PermissionDenied,
+//	404 NotFound, 409 AlreadyExists, 429 ResourceExhausted, 499 Canceled,
+//	500 Internal, 501 Unimplemented, 503 Unavailable, 504 DeadlineExceeded
+//
+// HTTP statuses not listed are never checked and cannot be inferred from.
+var httpToGRPC = map[int][]int{
+	200: {0},
+	400: {3, 9, 11},
+	401: {16},
+	403: {7},
+	404: {5},
+	409: {6, 10},
+	429: {8},
+	499: {1},
+	500: {13, 2, 15},
+	501: {12},
+	503: {14},
+	504: {4},
+}
+
+// ReconcileMapping checks each definition's gRPC code against its HTTP
+// status and returns a message per disagreement. When fix is true the gRPC
+// code is replaced in place by the canonical code for the HTTP status.
+func ReconcileMapping(errors []ErrorDefinition, fix bool) []string {
+	var messages []string
+	for i := range errors {
+		errDef := &errors[i]
+		allowed, ok := httpToGRPC[errDef.HTTP]
+		if !ok || containsInt(allowed, errDef.GRPC) {
+			continue
+		}
+
+		if fix {
+			messages = append(messages, fmt.Sprintf("%s: grpc corrected from %d to %d to match http %d", errDef.Key, errDef.GRPC, allowed[0], errDef.HTTP))
+			errDef.GRPC = allowed[0]
+		} else {
+			messages = append(messages, fmt.Sprintf("%s: http %d usually maps to grpc %d, got %d", errDef.Key, errDef.HTTP, allowed[0], errDef.GRPC))
+		}
+	}
+	return messages
+}
+
+func containsInt(values []int, v int) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
+
+// grpcUnset marks a definition whose grpc field was omitted from the input.
+const grpcUnset = -1
+
+// inferGRPC fills in omitted gRPC codes with the canonical code for the
+// definition's HTTP status. Explicit gRPC codes are left untouched.
+func inferGRPC(errors []ErrorDefinition) error {
+	for i := range errors {
+		if errors[i].GRPC != grpcUnset {
+			continue
+		}
+		allowed, ok := httpToGRPC[errors[i].HTTP]
+		if !ok {
+			return fmt.Errorf("error definition %d: grpc code is missing and cannot be inferred from http %d", i, errors[i].HTTP)
+		}
+		errors[i].GRPC = allowed[0]
+	}
+	return nil
+}
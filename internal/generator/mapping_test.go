@@ -0,0 +1,100 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReconcileMapping_Warn(t *testing.T) {
+	errors := []ErrorDefinition{
+		{Code: 20001, Key: "PolicyNotFound", Message: "Policy not found", HTTP: 404, GRPC: 3},
+		{Code: 20002, Key: "InvalidKind", Message: "Invalid policy kind", HTTP: 400, GRPC: 9},
+		{Code: 20003, Key: "Teapot", Message: "I'm a teapot", HTTP: 418, GRPC: 2},
+	}
+
+	messages := ReconcileMapping(errors, false)
+
+	if len(messages) != 1 {
+		t.Fatalf("Expected 1 mismatch, got %d: %v", len(messages), messages)
+	}
+	if !strings.Contains(messages[0], "PolicyNotFound: http 404 usually maps to grpc 5, got 3") {
+		t.Errorf("Unexpected warning: %s", messages[0])
+	}
+	if errors[0].GRPC != 3 {
+		t.Errorf("Expected gRPC code to be left unchanged without fix, got %d", errors[0].GRPC)
+	}
+}
+
+func TestReconcileMapping_Fix(t *testing.T) {
+	errors := []ErrorDefinition{
+		{Code: 20001, Key: "PolicyNotFound", Message: "Policy not found", HTTP: 404, GRPC: 3},
+	}
+
+	messages := ReconcileMapping(errors, true)
+
+	if len(messages) != 1 || !strings.Contains(messages[0], "grpc corrected from 3 to 5") {
+		t.Errorf("Expected correction message, got %v", messages)
+	}
+
+	code, err := Generate(Config{Package: "testpkg", Errors: errors})
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+	if !strings.Contains(string(code), "PolicyNotFoundGRPC codes.Code = 5") {
+		t.Errorf("Generated code should contain corrected gRPC code, got:\n%s", code)
+	}
+}
+
+func TestParseInput_InferGRPC(t *testing.T) {
+	yamlInput := `
+- code: 20001
+  key: PolicyNotFound
+  message: Policy not found
+  http: 404
+- code: 20002
+  key: InvalidKind
+  message: Invalid policy kind
+  http: 400
+  grpc: 9
+- code: 20003
+  key: Ok
+  message: Explicit OK
+  http: 404
+  grpc: 0
+`
+
+	errors, err := ParseInput(strings.NewReader(yamlInput), "test.yaml")
+	if err != nil {
+		t.Fatalf("Failed to parse YAML: %v", err)
+	}
+
+	expected := []int{5, 9, 0}
+	for i, want := range expected {
+		if errors[i].GRPC != want {
+			t.Errorf("Expected definition %d gRPC %d, got %d", i, want, errors[i].GRPC)
+		}
+	}
+
+	jsonInput := `[{"code": 20001, "key": "Unauthorized", "message": "Unauthorized", "http": 401}]`
+	errors, err = ParseInput(strings.NewReader(jsonInput), "test.json")
+	if err != nil {
+		t.Fatalf("Failed to parse JSON: %v", err)
+	}
+	if errors[0].GRPC != 16 {
+		t.Errorf("Expected inferred gRPC 16 for http 401, got %d", errors[0].GRPC)
+	}
+}
+
+func TestParseInput_InferGRPC_NoMapping(t *testing.T) {
+	yamlInput := `
+- code: 20001
+  key: Teapot
+  message: I'm a teapot
+  http: 418
+`
+
+	_, err := ParseInput(strings.NewReader(yamlInput), "test.yaml")
+	if err == nil || !strings.Contains(err.Error(), "cannot be inferred from http 418") {
+		t.Errorf("Expected inference error for http 418, got %v", err)
+	}
+}
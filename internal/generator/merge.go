@@ -0,0 +1,58 @@
+package generator
+
+import "fmt"
+
+// Duplicate handling modes for MergeDefinitions.
+const (
+	// OnDuplicateError rejects a key defined by more than one input.
+	OnDuplicateError = "error"
+	// OnDuplicateLastWins keeps the definition from the latest input.
+	OnDuplicateLastWins = "last-wins"
+	// OnDuplicateFirstWins keeps the definition from the earliest input.
+	OnDuplicateFirstWins = "first-wins"
+)
+
+// MergeDefinitions concatenates the definitions parsed from several inputs,
+// such as a base file followed by environment overrides. A key defined by
+// more than one input is handled according to onDuplicate: OnDuplicateError
+// returns an error, OnDuplicateLastWins replaces the earlier definition and
+// OnDuplicateFirstWins ignores the later one; an empty onDuplicate means
+// OnDuplicateError. A kept definition stays at the position the key first
+// appeared. Duplicates within a single input are left for Generate to reject.
+func MergeDefinitions(inputs [][]ErrorDefinition, onDuplicate string) ([]ErrorDefinition, error) {
+	switch onDuplicate {
+	case "":
+		onDuplicate = OnDuplicateError
+	case OnDuplicateError, OnDuplicateLastWins, OnDuplicateFirstWins:
+	default:
+		return nil, fmt.Errorf("unsupported duplicate mode %q: expected %s, %s or %s", onDuplicate, OnDuplicateError, OnDuplicateLastWins, OnDuplicateFirstWins)
+	}
+
+	type origin struct {
+		input int // index of the input that defined the key
+		index int // position of the definition in the result
+	}
+
+	var merged []ErrorDefinition
+	seen := make(map[string]origin)
+	for i, defs := range inputs {
+		for _, errDef := range defs {
+			first, exists := seen[errDef.Key]
+			if !exists || first.input == i {
+				seen[errDef.Key] = origin{input: i, index: len(merged)}
+				merged = append(merged, errDef)
+				continue
+			}
+
+			switch onDuplicate {
+			case OnDuplicateError:
+				return nil, fmt.Errorf("key %s of input %d is already defined by input %d", errDef.Key, i, first.input)
+			case OnDuplicateLastWins:
+				merged[first.index] = errDef
+				seen[errDef.Key] = origin{input: i, index: first.index}
+			}
+		}
+	}
+
+	return merged, nil
+}
@@ -0,0 +1,70 @@
+package generator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMergeDefinitions(t *testing.T) {
+	base := []ErrorDefinition{
+		{Code: 20001, Key: "PolicyNotFound", Message: "Policy not found", HTTP: 404, GRPC: 5},
+		{Code: 20002, Key: "InvalidKind", Message: "Invalid policy kind", HTTP: 400, GRPC: 3},
+	}
+	override := []ErrorDefinition{
+		{Code: 20001, Key: "PolicyNotFound", Message: "No such policy", HTTP: 404, GRPC: 5},
+		{Code: 20003, Key: "Conflict", Message: "Policy conflict", HTTP: 409, GRPC: 6},
+	}
+
+	tests := []struct {
+		mode     string
+		expected []ErrorDefinition
+	}{
+		{OnDuplicateLastWins, []ErrorDefinition{override[0], base[1], override[1]}},
+		{OnDuplicateFirstWins, []ErrorDefinition{base[0], base[1], override[1]}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			merged, err := MergeDefinitions([][]ErrorDefinition{base, override}, tt.mode)
+			if err != nil {
+				t.Fatalf("Failed to merge: %v", err)
+			}
+			if !reflect.DeepEqual(merged, tt.expected) {
+				t.Errorf("Expected %+v, got %+v", tt.expected, merged)
+			}
+		})
+	}
+
+	t.Run(OnDuplicateError, func(t *testing.T) {
+		_, err := MergeDefinitions([][]ErrorDefinition{base, override}, OnDuplicateError)
+		expected := "key PolicyNotFound of input 1 is already defined by input 0"
+		if err == nil || err.Error() != expected {
+			t.Errorf("Expected %q, got %v", expected, err)
+		}
+
+		merged, err := MergeDefinitions([][]ErrorDefinition{base, override[1:]}, OnDuplicateError)
+		if err != nil || len(merged) != 3 {
+			t.Errorf("Expected distinct keys to merge, got %v, %v", merged, err)
+		}
+	})
+}
+
+func TestMergeDefinitions_SameInput(t *testing.T) {
+	defs := []ErrorDefinition{{Code: 1, Key: "A"}, {Code: 2, Key: "A"}}
+
+	merged, err := MergeDefinitions([][]ErrorDefinition{defs}, OnDuplicateLastWins)
+	if err != nil {
+		t.Fatalf("Failed to merge: %v", err)
+	}
+	if len(merged) != 2 {
+		t.Errorf("Expected duplicates within an input to be kept for Generate to reject, got %+v", merged)
+	}
+}
+
+func TestMergeDefinitions_UnknownMode(t *testing.T) {
+	_, err := MergeDefinitions(nil, "newest")
+	if err == nil || !strings.Contains(err.Error(), `unsupported duplicate mode "newest"`) {
+		t.Errorf("Expected an unsupported mode error, got %v", err)
+	}
+}
@@ -0,0 +1,231 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// openAPISpec is the subset of an OpenAPI document read by ParseOpenAPI.
+type openAPISpec struct {
+	Components struct {
+		Schemas map[string]openAPISchema `yaml:"schemas"`
+	} `yaml:"components"`
+}
+
+// openAPISchema is an error body schema. Its HTTP status is carried by the
+// x-http-status extension since component schemas are not bound to a status.
+type openAPISchema struct {
+	HTTPStatus int                        `yaml:"x-http-status"`
+	Properties map[string]openAPIProperty `yaml:"properties"`
+}
+
+// openAPIProperty is the code property of an error schema. The enum lists the
+// codes, and the x-enum-* extensions (as used by openapi-generator) name them.
+type openAPIProperty struct {
+	Enum         []uint64 `yaml:"enum"`
+	VarNames     []string `yaml:"x-enum-varnames"`
+	Descriptions []string `yaml:"x-enum-descriptions"`
+	GRPCCodes    []int    `yaml:"x-grpc-codes"`
+}
+
+// ParseOpenAPI reads an OpenAPI document (YAML or JSON) and derives error
+// definitions from its component schemas. A schema contributes definitions
+// when it has an x-http-status extension and a "code" property whose enum is
+// annotated with x-enum-varnames (keys) and x-enum-descriptions (messages),
+// and optionally x-grpc-codes. Entries without a gRPC code default to 2
+// (Unknown). Definitions are returned sorted by code.
+func ParseOpenAPI(reader io.Reader) ([]ErrorDefinition, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read input: %w", err)
+	}
+
+	var spec openAPISpec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI spec: %w", err)
+	}
+
+	var errors []ErrorDefinition
+	for name, schema := range spec.Components.Schemas {
+		prop, ok := schema.Properties["code"]
+		if !ok || schema.HTTPStatus == 0 || len(prop.Enum) == 0 || len(prop.VarNames) == 0 {
+			continue
+		}
+		if len(prop.VarNames) != len(prop.Enum) || len(prop.Descriptions) != len(prop.Enum) {
+			return nil, fmt.Errorf("schema %s: x-enum-varnames and x-enum-descriptions must match the code enum length", name)
+		}
+		if prop.GRPCCodes != nil && len(prop.GRPCCodes) != len(prop.Enum) {
+			return nil, fmt.Errorf("schema %s: x-grpc-codes must match the code enum length", name)
+		}
+
+		for i, code := range prop.Enum {
+			grpc := 2
+			if prop.GRPCCodes != nil {
+				grpc = prop.GRPCCodes[i]
+			}
+			errors = append(errors, ErrorDefinition{
+				Code:    code,
+				Key:     prop.VarNames[i],
+				Message: prop.Descriptions[i],
+				HTTP:    schema.HTTPStatus,
+				GRPC:    grpc,
+			})
+		}
+	}
+
+	if len(errors) == 0 {
+		return nil, fmt.Errorf("no error code enums found in OpenAPI spec")
+	}
+
+	sort.Slice(errors, func(i, j int) bool { return errors[i].Code < errors[j].Code })
+
+	if err := validate(errors); err != nil {
+		return nil, err
+	}
+
+	return errors, nil
+}
+
+// GenerateOpenAPI creates an OpenAPI 3 document whose components describe the
+// error responses of the catalog. For each distinct HTTP status it emits a
+// response named after the status (e.g. NotFound) referencing a schema (e.g.
+// NotFoundError) for the {code, message} body, whose code enum lists the
+// codes returned with that status. The enum carries the same x-http-status,
+// x-enum-varnames, x-enum-descriptions and x-grpc-codes extensions that
+// ParseOpenAPI reads, so the document can be read back as definitions.
+func GenerateOpenAPI(errors []ErrorDefinition) ([]byte, error) {
+	byStatus := make(map[int][]ErrorDefinition)
+	for _, errDef := range errors {
+		byStatus[errDef.HTTP] = append(byStatus[errDef.HTTP], errDef)
+	}
+
+	schemas := make(map[string]openAPIErrorSchema)
+	responses := make(map[string]openAPIResponse)
+	for status, defs := range byStatus {
+		sort.Slice(defs, func(i, j int) bool { return defs[i].Code < defs[j].Code })
+
+		code := openAPICodeProperty{Type: "integer", Format: "int64"}
+		for _, errDef := range defs {
+			code.Enum = append(code.Enum, errDef.Code)
+			code.VarNames = append(code.VarNames, errDef.Key)
+			code.Descriptions = append(code.Descriptions, errDef.Message)
+			code.GRPCCodes = append(code.GRPCCodes, errDef.GRPC)
+		}
+
+		name := openAPIStatusName(status)
+		schemas[name+"Error"] = openAPIErrorSchema{
+			Type:       "object",
+			Required:   []string{"code", "message"},
+			HTTPStatus: status,
+			Properties: openAPIErrorProperties{Code: code, Message: openAPIType{Type: "string"}},
+		}
+
+		response := openAPIResponse{Description: http.StatusText(status)}
+		if response.Description == "" {
+			response.Description = fmt.Sprintf("HTTP %d", status)
+		}
+		response.Content.JSON.Schema.Ref = "#/components/schemas/" + name + "Error"
+		responses[name] = response
+	}
+
+	doc := openAPIDocument{OpenAPI: "3.0.3", Paths: map[string]any{}}
+	doc.Info.Title = "Error responses"
+	doc.Info.Version = "1.0.0"
+	doc.Components.Schemas = schemas
+	doc.Components.Responses = responses
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return nil, fmt.Errorf("failed to encode OpenAPI document: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// openAPIStatusName names the components for an HTTP status after its status
+// text in CamelCase ("Not Found" becomes NotFound), or Status<code> for
+// statuses without one.
+func openAPIStatusName(status int) string {
+	var name strings.Builder
+	for _, word := range strings.Fields(http.StatusText(status)) {
+		upper := true
+		for _, r := range word {
+			if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+				continue
+			}
+			if upper {
+				r = unicode.ToUpper(r)
+				upper = false
+			}
+			name.WriteRune(r)
+		}
+	}
+	if name.Len() == 0 {
+		return fmt.Sprintf("Status%d", status)
+	}
+	return name.String()
+}
+
+// openAPIDocument is the document written by GenerateOpenAPI.
+type openAPIDocument struct {
+	OpenAPI string `yaml:"openapi"`
+	Info    struct {
+		Title   string `yaml:"title"`
+		Version string `yaml:"version"`
+	} `yaml:"info"`
+	Paths      map[string]any `yaml:"paths"`
+	Components struct {
+		Responses map[string]openAPIResponse    `yaml:"responses"`
+		Schemas   map[string]openAPIErrorSchema `yaml:"schemas"`
+	} `yaml:"components"`
+}
+
+// openAPIResponse is a response component referencing an error schema.
+type openAPIResponse struct {
+	Description string `yaml:"description"`
+	Content     struct {
+		JSON struct {
+			Schema struct {
+				Ref string `yaml:"$ref"`
+			} `yaml:"schema"`
+		} `yaml:"application/json"`
+	} `yaml:"content"`
+}
+
+// openAPIErrorSchema is the schema of an error body for one HTTP status.
+type openAPIErrorSchema struct {
+	Type       string                 `yaml:"type"`
+	Required   []string               `yaml:"required"`
+	HTTPStatus int                    `yaml:"x-http-status"`
+	Properties openAPIErrorProperties `yaml:"properties"`
+}
+
+// openAPIErrorProperties lists the properties of an error body.
+type openAPIErrorProperties struct {
+	Code    openAPICodeProperty `yaml:"code"`
+	Message openAPIType         `yaml:"message"`
+}
+
+// openAPICodeProperty is the code property with its annotated enum, the
+// written counterpart of openAPIProperty.
+type openAPICodeProperty struct {
+	Type         string   `yaml:"type"`
+	Format       string   `yaml:"format"`
+	Enum         []uint64 `yaml:"enum"`
+	VarNames     []string `yaml:"x-enum-varnames"`
+	Descriptions []string `yaml:"x-enum-descriptions"`
+	GRPCCodes    []int    `yaml:"x-grpc-codes"`
+}
+
+// openAPIType is a property described only by its type.
+type openAPIType struct {
+	Type string `yaml:"type"`
+}
@@ -0,0 +1,150 @@
+package generator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestParseOpenAPI(t *testing.T) {
+	spec := `
+openapi: 3.0.3
+info:
+  title: Policy API
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Error400:
+      type: object
+      x-http-status: 400
+      properties:
+        code:
+          type: integer
+          enum: [20002]
+          x-enum-varnames: [InvalidKind]
+          x-enum-descriptions: [Invalid policy kind]
+          x-grpc-codes: [3]
+        message:
+          type: string
+    Error404:
+      type: object
+      x-http-status: 404
+      properties:
+        code:
+          type: integer
+          enum: [20001]
+          x-enum-varnames: [PolicyNotFound]
+          x-enum-descriptions: [Policy not found]
+        message:
+          type: string
+    Policy:
+      type: object
+      properties:
+        id:
+          type: string
+`
+
+	errors, err := ParseOpenAPI(strings.NewReader(spec))
+	if err != nil {
+		t.Fatalf("Failed to parse OpenAPI spec: %v", err)
+	}
+
+	expected := []ErrorDefinition{
+		{Code: 20001, Key: "PolicyNotFound", Message: "Policy not found", HTTP: 404, GRPC: 2},
+		{Code: 20002, Key: "InvalidKind", Message: "Invalid policy kind", HTTP: 400, GRPC: 3},
+	}
+	if len(errors) != len(expected) {
+		t.Fatalf("Expected %d errors, got %d", len(expected), len(errors))
+	}
+	for i, want := range expected {
+		if !reflect.DeepEqual(errors[i], want) {
+			t.Errorf("Expected error %d to be %+v, got %+v", i, want, errors[i])
+		}
+	}
+}
+
+func TestParseOpenAPI_MismatchedEnum(t *testing.T) {
+	spec := `
+components:
+  schemas:
+    Error404:
+      x-http-status: 404
+      properties:
+        code:
+          enum: [20001, 20002]
+          x-enum-varnames: [PolicyNotFound]
+          x-enum-descriptions: [Policy not found]
+`
+
+	_, err := ParseOpenAPI(strings.NewReader(spec))
+	if err == nil || !strings.Contains(err.Error(), "must match the code enum length") {
+		t.Errorf("Expected enum length mismatch error, got %v", err)
+	}
+}
+
+func TestParseOpenAPI_NoErrors(t *testing.T) {
+	_, err := ParseOpenAPI(strings.NewReader("openapi: 3.0.3\npaths: {}\n"))
+	if err == nil {
+		t.Error("Expected error for spec without error code enums")
+	}
+}
+
+func TestGenerateOpenAPI(t *testing.T) {
+	errors := []ErrorDefinition{
+		{Code: 20002, Key: "RuleNotFound", Message: "Rule not found", HTTP: 404, GRPC: 5},
+		{Code: 20001, Key: "PolicyNotFound", Message: "Policy not found", HTTP: 404, GRPC: 5},
+		{Code: 20003, Key: "InvalidKind", Message: "Invalid policy kind", HTTP: 400, GRPC: 3},
+		{Code: 20004, Key: "ClientGone", Message: "Client closed request", HTTP: 499, GRPC: 1},
+	}
+
+	out, err := GenerateOpenAPI(errors)
+	if err != nil {
+		t.Fatalf("Failed to generate OpenAPI document: %v", err)
+	}
+
+	var doc struct {
+		Components struct {
+			Responses map[string]struct {
+				Content map[string]struct {
+					Schema map[string]string `yaml:"schema"`
+				} `yaml:"content"`
+			} `yaml:"responses"`
+			Schemas map[string]struct {
+				Properties struct {
+					Code struct {
+						Enum []uint64 `yaml:"enum"`
+					} `yaml:"code"`
+				} `yaml:"properties"`
+			} `yaml:"schemas"`
+		} `yaml:"components"`
+	}
+	if err := yaml.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("Failed to decode generated document: %v\n%s", err, out)
+	}
+
+	responses := doc.Components.Responses
+	if len(responses) != 3 {
+		t.Errorf("Expected 3 responses, got %d", len(responses))
+	}
+	for name, schema := range map[string]string{"NotFound": "NotFoundError", "BadRequest": "BadRequestError", "Status499": "Status499Error"} {
+		ref := responses[name].Content["application/json"].Schema["$ref"]
+		if ref != "#/components/schemas/"+schema {
+			t.Errorf("Expected response %s to reference %s, got %q", name, schema, ref)
+		}
+	}
+
+	if enum := doc.Components.Schemas["NotFoundError"].Properties.Code.Enum; !reflect.DeepEqual(enum, []uint64{20001, 20002}) {
+		t.Errorf("Expected NotFoundError code enum [20001 20002], got %v", enum)
+	}
+
+	parsed, err := ParseOpenAPI(strings.NewReader(string(out)))
+	if err != nil {
+		t.Fatalf("Failed to parse generated document: %v", err)
+	}
+	if len(parsed) != len(errors) || parsed[1].Key != "RuleNotFound" || parsed[1].GRPC != 5 {
+		t.Errorf("Expected the definitions to round-trip, got %+v", parsed)
+	}
+}
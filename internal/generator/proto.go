@@ -0,0 +1,154 @@
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	protoEnumRe   = regexp.MustCompile(`\benum\s+(\w+)\s*\{`)
+	protoValueRe  = regexp.MustCompile(`(\w+)\s*=\s*(-?\d+)\s*(?:\[([^\]]*)\])?\s*;`)
+	protoOptionRe = regexp.MustCompile(`\(([\w.]+)\)\s*=\s*("(?:[^"\\]|\\.)*"|[\w.+-]+)`)
+)
+
+// parseProtoBytes derives error definitions from the values of the enums in
+// .proto source, for ParseInputBytes. A value contributes a definition when
+// it carries custom options named message, http, grpc, desc, suggestion,
+// category, group or key (under any extension package), for example:
+//
+//	enum ErrorCode {
+//	  ERROR_CODE_UNSPECIFIED = 0;
+//	  POLICY_NOT_FOUND = 20001 [(rescode.message) = "Policy not found", (rescode.http) = 404, (rescode.grpc) = 5];
+//	}
+//
+// Values without options, such as the zero value, are skipped. Keys default
+// to the value name converted to CamelCase. gRPC codes are inferred and the
+// definitions validated by the caller, as for YAML and JSON input.
+func parseProtoBytes(data []byte) ([]ErrorDefinition, error) {
+	src := stripProtoComments(string(data))
+
+	var errors []ErrorDefinition
+	for _, loc := range protoEnumRe.FindAllStringSubmatchIndex(src, -1) {
+		enumName := src[loc[2]:loc[3]]
+		body := src[loc[1]:]
+		if end := strings.IndexByte(body, '}'); end >= 0 {
+			body = body[:end]
+		}
+
+		for _, m := range protoValueRe.FindAllStringSubmatch(body, -1) {
+			if strings.TrimSpace(m[3]) == "" {
+				continue
+			}
+			def, err := protoDefinition(m[1], m[2], m[3])
+			if err != nil {
+				return nil, fmt.Errorf("enum %s value %s: %w", enumName, m[1], err)
+			}
+			errors = append(errors, def)
+		}
+	}
+
+	if len(errors) == 0 {
+		return nil, fmt.Errorf("no annotated enum values found in proto file")
+	}
+
+	return errors, nil
+}
+
+// protoDefinition builds a definition from an enum value name, number and
+// the contents of its option brackets.
+func protoDefinition(name, number, options string) (ErrorDefinition, error) {
+	code, err := strconv.ParseUint(number, 10, 64)
+	if err != nil {
+		return ErrorDefinition{}, fmt.Errorf("invalid code %s", number)
+	}
+
+	def := ErrorDefinition{Code: code, Key: protoKey(name), GRPC: grpcUnset}
+	for _, opt := range protoOptionRe.FindAllStringSubmatch(options, -1) {
+		field := opt[1][strings.LastIndexByte(opt[1], '.')+1:]
+		value, quoted := opt[2], strings.HasPrefix(opt[2], `"`)
+		if quoted {
+			if value, err = strconv.Unquote(value); err != nil {
+				return ErrorDefinition{}, fmt.Errorf("option %s: invalid string %s", opt[1], opt[2])
+			}
+		}
+
+		switch field {
+		case "message":
+			def.Message = value
+		case "desc":
+			def.Desc = value
+		case "suggestion":
+			def.Suggestion = value
+		case "key":
+			def.Key = value
+		case "category":
+			def.Category = value
+		case "group":
+			def.Group = value
+		case "http", "grpc":
+			n, err := strconv.Atoi(value)
+			if err != nil || quoted {
+				return ErrorDefinition{}, fmt.Errorf("option %s: %s is not an integer", opt[1], value)
+			}
+			if field == "http" {
+				def.HTTP = n
+			} else {
+				def.GRPC = n
+			}
+		}
+	}
+
+	return def, nil
+}
+
+// protoKey converts an UPPER_SNAKE_CASE enum value name to CamelCase.
+func protoKey(name string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(strings.ToLower(name), "_") {
+		if part == "" {
+			continue
+		}
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+// stripProtoComments removes // and /* */ comments, leaving string literals
+// untouched.
+func stripProtoComments(src string) string {
+	var b strings.Builder
+	for i := 0; i < len(src); i++ {
+		switch {
+		case src[i] == '"':
+			j := i + 1
+			for j < len(src) && src[j] != '"' {
+				if src[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j >= len(src) {
+				j = len(src) - 1
+			}
+			b.WriteString(src[i : j+1])
+			i = j
+		case strings.HasPrefix(src[i:], "//"):
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+			b.WriteByte('\n')
+		case strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				return b.String()
+			}
+			i += end + 3
+			b.WriteByte(' ')
+		default:
+			b.WriteByte(src[i])
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,78 @@
+package generator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseProto(t *testing.T) {
+	src := `
+syntax = "proto3";
+
+package policy.v1;
+
+import "rescode/options.proto";
+
+// ErrorCode lists the policy service errors.
+enum ErrorCode {
+  ERROR_CODE_UNSPECIFIED = 0;
+  POLICY_NOT_FOUND = 20001 [
+    (rescode.message) = "Policy not found",
+    (rescode.http) = 404,
+    (rescode.grpc) = 5,
+    (rescode.desc) = "Policy could not be located // in the database"
+  ];
+  /* grpc is inferred from the HTTP status */
+  INVALID_KIND = 20002 [(rescode.message) = "Invalid \"kind\"", (rescode.http) = 400];
+}
+`
+
+	errors, err := ParseInputBytes([]byte(src), "errors.proto")
+	if err != nil {
+		t.Fatalf("Failed to parse proto: %v", err)
+	}
+
+	expected := []ErrorDefinition{
+		{Code: 20001, Key: "PolicyNotFound", Message: "Policy not found", HTTP: 404, GRPC: 5, Desc: "Policy could not be located // in the database"},
+		{Code: 20002, Key: "InvalidKind", Message: `Invalid "kind"`, HTTP: 400, GRPC: 3},
+	}
+	if len(errors) != len(expected) {
+		t.Fatalf("Expected %d errors, got %d", len(expected), len(errors))
+	}
+	for i, want := range expected {
+		if !reflect.DeepEqual(errors[i], want) {
+			t.Errorf("Expected error %d to be %+v, got %+v", i, want, errors[i])
+		}
+	}
+}
+
+func TestParseProto_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+	}{
+		{"no annotated values", "enum E { E_UNSPECIFIED = 0; }"},
+		{"missing message", "enum E { NOT_FOUND = 1 [(rescode.http) = 404]; }"},
+		{"non-integer http", `enum E { NOT_FOUND = 1 [(rescode.message) = "x", (rescode.http) = "404"]; }`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseInputBytes([]byte(tt.src), "errors.proto"); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
+
+func TestParseInputBytes_Proto(t *testing.T) {
+	src := `enum E { NOT_FOUND = 1 [(rescode.message) = "Not found", (rescode.http) = 404]; }`
+
+	errors, err := ParseInputBytes([]byte(src), "errors.proto")
+	if err != nil {
+		t.Fatalf("Failed to parse proto input: %v", err)
+	}
+	if len(errors) != 1 || errors[0].Key != "NotFound" || errors[0].GRPC != 5 {
+		t.Errorf("Expected NotFound with gRPC 5, got %+v", errors)
+	}
+}
@@ -0,0 +1,119 @@
+package generator
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// requiredFields lists the ErrorDefinition fields that validate rejects when
+// missing. grpc is optional because it can be inferred from http.
+var requiredFields = []string{"code", "key", "message", "http"}
+
+// schemaConstraints holds the validation rules for fields whose JSON Schema
+// cannot be derived from the Go type alone.
+var schemaConstraints = map[string]map[string]interface{}{
+	"code":       {"minimum": 1},
+	"key":        {"minLength": 1, "pattern": "^[A-Za-z_][A-Za-z0-9_]*$"},
+	"message":    {"minLength": 1},
+	"http":       {"minimum": 1},
+	"grpc":       {"minimum": 0, "maximum": 16},
+	"category":   {"pattern": "^[A-Za-z_][A-Za-z0-9_]*$"},
+	"group":      {"pattern": "^[A-Za-z_][A-Za-z0-9_]*$"},
+	"deprecated": {"oneOf": []interface{}{map[string]interface{}{"type": "boolean"}, map[string]interface{}{"type": "string"}}},
+}
+
+// GenerateSchema creates a JSON Schema describing the input file format:
+// either an array of error definitions or an object with an errors array and
+// an optional defaults object. Properties are derived from the JSON tags of
+// ErrorDefinition so the schema stays in sync with the parser.
+func GenerateSchema() ([]byte, error) {
+	properties := make(map[string]interface{})
+
+	t := reflect.TypeOf(ErrorDefinition{})
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		property := make(map[string]interface{})
+		if jsonType := schemaType(field.Type); jsonType != "" {
+			property["type"] = jsonType
+		}
+		for key, value := range schemaConstraints[name] {
+			property[key] = value
+		}
+		properties[name] = property
+	}
+
+	// Entries of the object form may take any field from defaults except
+	// code and key, which must be unique
+	schema := map[string]interface{}{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"title":   "rescode error definitions",
+		"$defs": map[string]interface{}{
+			"definition": map[string]interface{}{
+				"type":                 "object",
+				"properties":           properties,
+				"required":             requiredFields,
+				"additionalProperties": false,
+			},
+			"entry": map[string]interface{}{
+				"type":                 "object",
+				"properties":           properties,
+				"required":             []string{"code", "key"},
+				"additionalProperties": false,
+			},
+			"defaults": map[string]interface{}{
+				"type":                 "object",
+				"properties":           properties,
+				"additionalProperties": false,
+			},
+		},
+		"oneOf": []interface{}{
+			map[string]interface{}{
+				"type":  "array",
+				"items": map[string]interface{}{"$ref": "#/$defs/definition"},
+			},
+			map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"defaults": map[string]interface{}{"$ref": "#/$defs/defaults"},
+					"errors": map[string]interface{}{
+						"type":  "array",
+						"items": map[string]interface{}{"$ref": "#/$defs/entry"},
+					},
+				},
+				"required":             []string{"errors"},
+				"additionalProperties": false,
+			},
+		},
+	}
+
+	out, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(out, '\n'), nil
+}
+
+// schemaType maps a Go field type to its JSON Schema type, or "" when the
+// type is described by a constraint instead.
+func schemaType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Map:
+		return "object"
+	case reflect.Slice:
+		return "array"
+	}
+	return ""
+}
@@ -0,0 +1,94 @@
+package generator
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestGenerateSchema(t *testing.T) {
+	out, err := GenerateSchema()
+	if err != nil {
+		t.Fatalf("Failed to generate schema: %v", err)
+	}
+
+	var schema struct {
+		Defs struct {
+			Definition struct {
+				Required   []string `json:"required"`
+				Properties map[string]struct {
+					Type    string   `json:"type"`
+					Minimum *float64 `json:"minimum"`
+					Maximum *float64 `json:"maximum"`
+					OneOf   []any    `json:"oneOf"`
+				} `json:"properties"`
+			} `json:"definition"`
+		} `json:"$defs"`
+		OneOf []struct {
+			Type  string `json:"type"`
+			Items struct {
+				Ref string `json:"$ref"`
+			} `json:"items"`
+			Properties struct {
+				Defaults struct {
+					Ref string `json:"$ref"`
+				} `json:"defaults"`
+				Errors struct {
+					Type  string `json:"type"`
+					Items struct {
+						Ref string `json:"$ref"`
+					} `json:"items"`
+				} `json:"errors"`
+			} `json:"properties"`
+			Required []string `json:"required"`
+		} `json:"oneOf"`
+	}
+	if err := json.Unmarshal(out, &schema); err != nil {
+		t.Fatalf("Schema is not valid JSON: %v", err)
+	}
+
+	if len(schema.OneOf) != 2 {
+		t.Fatalf("Expected the list and object forms, got %d alternatives", len(schema.OneOf))
+	}
+	list, object := schema.OneOf[0], schema.OneOf[1]
+	if list.Type != "array" || list.Items.Ref != "#/$defs/definition" {
+		t.Errorf("Expected an array of definitions, got %+v", list)
+	}
+	if object.Type != "object" || object.Properties.Defaults.Ref != "#/$defs/defaults" ||
+		object.Properties.Errors.Type != "array" || object.Properties.Errors.Items.Ref != "#/$defs/entry" {
+		t.Errorf("Expected an object with defaults and errors, got %+v", object)
+	}
+	if len(object.Required) != 1 || object.Required[0] != "errors" {
+		t.Errorf("Expected errors to be required in the object form, got %v", object.Required)
+	}
+
+	definition := schema.Defs.Definition
+	required := map[string]bool{}
+	for _, name := range definition.Required {
+		required[name] = true
+	}
+	for _, name := range []string{"code", "key", "message", "http"} {
+		if !required[name] {
+			t.Errorf("Expected %s to be required", name)
+		}
+	}
+	if required["grpc"] {
+		t.Error("Expected grpc to be optional since it can be inferred")
+	}
+
+	grpc, ok := definition.Properties["grpc"]
+	if !ok {
+		t.Fatal("Expected schema to describe grpc")
+	}
+	if grpc.Type != "integer" || grpc.Minimum == nil || *grpc.Minimum != 0 || grpc.Maximum == nil || *grpc.Maximum != 16 {
+		t.Errorf("Expected grpc to be an integer in 0-16, got %+v", grpc)
+	}
+
+	for _, name := range []string{"desc", "category", "group"} {
+		if definition.Properties[name].Type != "string" {
+			t.Errorf("Expected %s to be a string property", name)
+		}
+	}
+	if len(definition.Properties["deprecated"].OneOf) != 2 {
+		t.Error("Expected deprecated to accept a boolean or a string")
+	}
+}
@@ -0,0 +1,49 @@
+package generator
+
+import "sort"
+
+// Summary holds statistics about a set of error definitions.
+type Summary struct {
+	Count   int         // Number of definitions
+	ByHTTP  map[int]int // Definition count per HTTP status
+	ByGRPC  map[int]int // Definition count per gRPC code
+	MinCode uint64      // Lowest code, 0 when there are no definitions
+	MaxCode uint64      // Highest code, 0 when there are no definitions
+	Gaps    []CodeRange // Unused code ranges between MinCode and MaxCode, in ascending order
+}
+
+// CodeRange is an inclusive range of codes.
+type CodeRange struct {
+	Min uint64
+	Max uint64
+}
+
+// Summarize computes statistics over errors: counts by HTTP status and gRPC
+// code, the lowest and highest code, and the gaps in the code sequence.
+func Summarize(errors []ErrorDefinition) Summary {
+	summary := Summary{
+		Count:  len(errors),
+		ByHTTP: make(map[int]int),
+		ByGRPC: make(map[int]int),
+	}
+	if len(errors) == 0 {
+		return summary
+	}
+
+	codes := make([]uint64, 0, len(errors))
+	for _, errDef := range errors {
+		summary.ByHTTP[errDef.HTTP]++
+		summary.ByGRPC[errDef.GRPC]++
+		codes = append(codes, errDef.Code)
+	}
+	sort.Slice(codes, func(i, j int) bool { return codes[i] < codes[j] })
+
+	summary.MinCode, summary.MaxCode = codes[0], codes[len(codes)-1]
+	for i := 1; i < len(codes); i++ {
+		if codes[i]-codes[i-1] > 1 {
+			summary.Gaps = append(summary.Gaps, CodeRange{Min: codes[i-1] + 1, Max: codes[i] - 1})
+		}
+	}
+
+	return summary
+}
@@ -0,0 +1,42 @@
+package generator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSummarize(t *testing.T) {
+	errors := []ErrorDefinition{
+		{Code: 20004, Key: "Conflict", HTTP: 409, GRPC: 6},
+		{Code: 20001, Key: "PolicyNotFound", HTTP: 404, GRPC: 5},
+		{Code: 20002, Key: "RuleNotFound", HTTP: 404, GRPC: 5},
+		{Code: 20008, Key: "InvalidKind", HTTP: 400, GRPC: 3},
+	}
+
+	summary := Summarize(errors)
+
+	if summary.Count != 4 {
+		t.Errorf("Expected count 4, got %d", summary.Count)
+	}
+	if summary.MinCode != 20001 || summary.MaxCode != 20008 {
+		t.Errorf("Expected codes 20001-20008, got %d-%d", summary.MinCode, summary.MaxCode)
+	}
+	if expected := map[int]int{404: 2, 409: 1, 400: 1}; !reflect.DeepEqual(summary.ByHTTP, expected) {
+		t.Errorf("Expected HTTP counts %v, got %v", expected, summary.ByHTTP)
+	}
+	if expected := map[int]int{5: 2, 6: 1, 3: 1}; !reflect.DeepEqual(summary.ByGRPC, expected) {
+		t.Errorf("Expected gRPC counts %v, got %v", expected, summary.ByGRPC)
+	}
+	expectedGaps := []CodeRange{{Min: 20003, Max: 20003}, {Min: 20005, Max: 20007}}
+	if !reflect.DeepEqual(summary.Gaps, expectedGaps) {
+		t.Errorf("Expected gaps %v, got %v", expectedGaps, summary.Gaps)
+	}
+}
+
+func TestSummarize_Empty(t *testing.T) {
+	summary := Summarize(nil)
+
+	if summary.Count != 0 || summary.MinCode != 0 || summary.MaxCode != 0 || summary.Gaps != nil {
+		t.Errorf("Expected an empty summary, got %+v", summary)
+	}
+}
@@ -0,0 +1,41 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"text/template"
+)
+
+// ParseTemplate compiles a custom generation template. The template is
+// executed with the Config, so it can range over .Errors and read each
+// ErrorDefinition's fields; printf "%q" quotes strings as Go literals.
+func ParseTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("rescodegen").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// generateFromTemplate executes config.Template in place of the built-in
+// layout, prepends the file header and formats the result.
+func generateFromTemplate(config Config) ([]byte, error) {
+	tmpl, err := ParseTemplate(config.Template)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(fileHeader(config))
+	if err := tmpl.Execute(&buf, config); err != nil {
+		return nil, fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated code: %w", err)
+	}
+
+	return formatted, nil
+}
@@ -0,0 +1,89 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerate_Template(t *testing.T) {
+	tmpl := `// Custom header: licensed under MIT.
+
+//go:build !nocodes
+
+package {{.Package}}
+
+// Codes generated from a custom template.
+var Codes = map[string]uint64{
+{{- range .Errors}}
+	{{printf "%q" .Key}}: {{.Code}}, // {{.Message}}
+{{- end}}
+}
+`
+
+	config := Config{
+		Package:  "testpkg",
+		Template: tmpl,
+		Errors: []ErrorDefinition{
+			{Code: 20001, Key: "PolicyNotFound", Message: "Policy not found", HTTP: 404, GRPC: 5},
+		},
+	}
+
+	code, err := Generate(config)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	codeStr := string(code)
+	expected := []string{
+		"// Custom header: licensed under MIT.",
+		"//go:build !nocodes",
+		"package testpkg",
+		`"PolicyNotFound": 20001, // Policy not found`,
+	}
+	for _, exp := range expected {
+		if !strings.Contains(codeStr, exp) {
+			t.Errorf("Generated code should contain %q", exp)
+		}
+	}
+	if !strings.HasPrefix(codeStr, "// Code generated by rescodegen. DO NOT EDIT.\n") {
+		t.Errorf("Template output should start with the DO NOT EDIT marker, got %q", strings.SplitN(codeStr, "\n", 2)[0])
+	}
+}
+
+func TestGenerate_TemplateHeader(t *testing.T) {
+	config := Config{
+		Package:  "testpkg",
+		Header:   "Copyright 2026 Example Corp.",
+		Template: "package {{.Package}}\n",
+	}
+
+	code, err := Generate(config)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	expected := "// Copyright 2026 Example Corp.\n\n// Code generated by rescodegen. DO NOT EDIT.\n\npackage testpkg\n"
+	if string(code) != expected {
+		t.Errorf("Expected template output %q, got %q", expected, string(code))
+	}
+}
+
+func TestGenerate_InvalidTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+	}{
+		{"does not compile", "package {{.Package"},
+		{"unknown field", "package {{.Pkg}}"},
+		{"invalid Go", "package {{.Package}}\nfunc {"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := Config{Package: "testpkg", Template: tt.template}
+			if _, err := Generate(config); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package generator
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// uuidPattern matches the canonical textual form of a UUID.
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// uuidNamespace is the UUIDv5 namespace for error keys, itself derived from
+// the RFC 4122 URL namespace and the module path.
+var uuidNamespace = uuidV5([16]byte{
+	0x6b, 0xa7, 0xb8, 0x11, 0x9d, 0xad, 0x11, 0xd1,
+	0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8,
+}, "https://github.com/restayway/rescode")
+
+// errorUUID returns the UUID emitted for errDef: its uuid in lower case,
+// otherwise a key-derived UUID when config.UUIDs is set, otherwise "".
+func errorUUID(config Config, errDef ErrorDefinition) string {
+	if errDef.UUID != "" {
+		return strings.ToLower(errDef.UUID)
+	}
+	if config.UUIDs {
+		return formatUUID(uuidV5(uuidNamespace, errDef.Key))
+	}
+	return ""
+}
+
+// uuidV5 returns the RFC 4122 version 5 (SHA-1) UUID for name in namespace.
+func uuidV5(namespace [16]byte, name string) [16]byte {
+	h := sha1.New()
+	h.Write(namespace[:])
+	h.Write([]byte(name))
+
+	var id [16]byte
+	copy(id[:], h.Sum(nil))
+	id[6] = id[6]&0x0f | 0x50
+	id[8] = id[8]&0x3f | 0x80
+	return id
+}
+
+// formatUUID renders id in the canonical 8-4-4-4-12 form.
+func formatUUID(id [16]byte) string {
+	return fmt.Sprintf("%x-%x-%x-%x-%x", id[0:4], id[4:6], id[6:8], id[8:10], id[10:16])
+}
@@ -0,0 +1,106 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestErrorUUID_Deterministic(t *testing.T) {
+	config := Config{UUIDs: true}
+	errDef := ErrorDefinition{Code: 20001, Key: "PolicyNotFound"}
+
+	first := errorUUID(config, errDef)
+	if first != "fbc488b4-234a-5ebc-8817-2228da1eb817" {
+		t.Errorf("Expected the RFC 4122 UUIDv5 of the key, got %s", first)
+	}
+	if again := errorUUID(config, errDef); again != first {
+		t.Errorf("Expected the same UUID on every call, got %s and %s", first, again)
+	}
+
+	other := errorUUID(config, ErrorDefinition{Code: 20002, Key: "InvalidKind"})
+	if other == first {
+		t.Error("Expected different keys to get different UUIDs")
+	}
+}
+
+func TestErrorUUID_Explicit(t *testing.T) {
+	errDef := ErrorDefinition{Key: "PolicyNotFound", UUID: "6F1C7D1E-8A4B-5C3D-9E2F-0A1B2C3D4E5F"}
+
+	if got := errorUUID(Config{}, errDef); got != "6f1c7d1e-8a4b-5c3d-9e2f-0a1b2c3d4e5f" {
+		t.Errorf("Expected the explicit uuid in lower case, got %s", got)
+	}
+	if got := errorUUID(Config{}, ErrorDefinition{Key: "PolicyNotFound"}); got != "" {
+		t.Errorf("Expected no UUID without UUIDs or uuid, got %s", got)
+	}
+}
+
+func TestGenerate_UUIDs(t *testing.T) {
+	config := Config{
+		Package: "testpkg",
+		Errors: []ErrorDefinition{
+			{Code: 20001, Key: "PolicyNotFound", Message: "Policy not found", HTTP: 404, GRPC: 5},
+		},
+		UUIDs: true,
+	}
+
+	code, err := Generate(config)
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+
+	codeStr := string(code)
+	expected := []string{
+		"PolicyNotFoundUUID",
+		`"fbc488b4-234a-5ebc-8817-2228da1eb817"`,
+		".WithUUID(PolicyNotFoundUUID)",
+	}
+	for _, exp := range expected {
+		if !strings.Contains(codeStr, exp) {
+			t.Errorf("Generated code should contain %q", exp)
+		}
+	}
+}
+
+func TestParseInput_InvalidUUID(t *testing.T) {
+	tests := []struct {
+		name  string
+		yaml  string
+		match string
+	}{
+		{
+			name: "malformed",
+			yaml: `- code: 20001
+  key: PolicyNotFound
+  message: Policy not found
+  http: 404
+  grpc: 5
+  uuid: not-a-uuid`,
+			match: "is not of the form",
+		},
+		{
+			name: "duplicate",
+			yaml: `- code: 20001
+  key: PolicyNotFound
+  message: Policy not found
+  http: 404
+  grpc: 5
+  uuid: 6f1c7d1e-8a4b-5c3d-9e2f-0a1b2c3d4e5f
+- code: 20002
+  key: InvalidKind
+  message: Invalid kind
+  http: 400
+  grpc: 3
+  uuid: 6F1C7D1E-8A4B-5C3D-9E2F-0A1B2C3D4E5F`,
+			match: "already used by PolicyNotFound",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseInput(strings.NewReader(tt.yaml), "errors.yaml")
+			if err == nil || !strings.Contains(err.Error(), tt.match) {
+				t.Errorf("Expected error containing %q, got %v", tt.match, err)
+			}
+		})
+	}
+}
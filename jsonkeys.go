@@ -0,0 +1,88 @@
+package rescode
+
+import "sync/atomic"
+
+// JSONKeys holds the key names used by JSON and MarshalJSON. Empty fields
+// fall back to the default names.
+type JSONKeys struct {
+	Code          string // default "code"
+	Message       string // default "message"
+	HTTPCode      string // default "httpCode"
+	RPCCode       string // default "rpcCode"
+	Data          string // default "data"
+	OriginalError string // default "originalError"
+	Service       string // default "service"
+	Suggestion    string // default "suggestion"
+	UUID          string // default "uuid"
+	Caller        string // default "caller"
+	Tags          string // default "tags"
+}
+
+// DefaultJSONKeys returns the default key names.
+func DefaultJSONKeys() JSONKeys {
+	return JSONKeys{
+		Code:          "code",
+		Message:       "message",
+		HTTPCode:      "httpCode",
+		RPCCode:       "rpcCode",
+		Data:          "data",
+		OriginalError: "originalError",
+		Service:       "service",
+		Suggestion:    "suggestion",
+		UUID:          "uuid",
+		Caller:        "caller",
+		Tags:          "tags",
+	}
+}
+
+var jsonKeys atomic.Pointer[JSONKeys]
+
+func init() {
+	keys := DefaultJSONKeys()
+	jsonKeys.Store(&keys)
+}
+
+// SetJSONKeys changes the key names used by JSON and MarshalJSON. It is safe
+// for concurrent use but is intended to be called once during initialization.
+func SetJSONKeys(keys JSONKeys) {
+	defaults := DefaultJSONKeys()
+	if keys.Code == "" {
+		keys.Code = defaults.Code
+	}
+	if keys.Message == "" {
+		keys.Message = defaults.Message
+	}
+	if keys.HTTPCode == "" {
+		keys.HTTPCode = defaults.HTTPCode
+	}
+	if keys.RPCCode == "" {
+		keys.RPCCode = defaults.RPCCode
+	}
+	if keys.Data == "" {
+		keys.Data = defaults.Data
+	}
+	if keys.OriginalError == "" {
+		keys.OriginalError = defaults.OriginalError
+	}
+	if keys.Service == "" {
+		keys.Service = defaults.Service
+	}
+	if keys.Suggestion == "" {
+		keys.Suggestion = defaults.Suggestion
+	}
+	if keys.UUID == "" {
+		keys.UUID = defaults.UUID
+	}
+	if keys.Caller == "" {
+		keys.Caller = defaults.Caller
+	}
+	if keys.Tags == "" {
+		keys.Tags = defaults.Tags
+	}
+	jsonKeys.Store(&keys)
+}
+
+// currentJSONKeys returns the key names currently in effect.
+func currentJSONKeys() *JSONKeys {
+	return jsonKeys.Load()
+}
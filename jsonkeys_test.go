@@ -0,0 +1,64 @@
+package rescode
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+)
+
+func TestSetJSONKeys(t *testing.T) {
+	t.Cleanup(func() { SetJSONKeys(DefaultJSONKeys()) })
+
+	SetJSONKeys(JSONKeys{Code: "error_code", HTTPCode: "http_status"})
+
+	rc := New(1201, 404, codes.NotFound, "not found", "extra")(errors.New("no rows"))
+	result := rc.JSON()
+
+	expected := map[string]interface{}{
+		"error_code":    uint64(1201),
+		"message":       "not found",
+		"http_status":   404,
+		"rpcCode":       int(codes.NotFound),
+		"data":          "extra",
+		"originalError": "no rows",
+	}
+	if len(result) != len(expected) {
+		t.Errorf("Expected %d keys, got %d: %v", len(expected), len(result), result)
+	}
+	for key, want := range expected {
+		if result[key] != want {
+			t.Errorf("Expected %s %v, got %v", key, want, result[key])
+		}
+	}
+
+	filtered := rc.JSON("error_code")
+	if len(filtered) != 1 || filtered["error_code"] != uint64(1201) {
+		t.Errorf("Expected filtering by custom key to work, got %v", filtered)
+	}
+}
+
+func TestRC_MarshalJSON(t *testing.T) {
+	t.Cleanup(func() { SetJSONKeys(DefaultJSONKeys()) })
+
+	rc := New(1202, 400, codes.InvalidArgument, "invalid")()
+
+	data, err := json.Marshal(rc)
+	if err != nil {
+		t.Fatalf("Failed to marshal RC: %v", err)
+	}
+	if string(data) != `{"code":1202,"httpCode":400,"message":"invalid","rpcCode":3}` {
+		t.Errorf("Unexpected default JSON: %s", data)
+	}
+
+	SetJSONKeys(JSONKeys{Code: "error_code", HTTPCode: "http_status"})
+
+	data, err = json.Marshal(rc)
+	if err != nil {
+		t.Fatalf("Failed to marshal RC: %v", err)
+	}
+	if string(data) != `{"error_code":1202,"http_status":400,"message":"invalid","rpcCode":3}` {
+		t.Errorf("Unexpected custom JSON: %s", data)
+	}
+}
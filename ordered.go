@@ -0,0 +1,85 @@
+package rescode
+
+import (
+	"bytes"
+	"encoding/json"
+	"sync/atomic"
+)
+
+// OrderedMarshalJSON makes MarshalJSON emit keys in the order configured with
+// SetJSONOrder, via OrderedJSON, instead of encoding/json's sorted map order.
+// It is off by default and should be set once at init.
+var OrderedMarshalJSON bool
+
+var jsonOrder atomic.Pointer[[]string]
+
+// SetJSONOrder sets the key order used by OrderedJSON. Keys are the output
+// names configured with SetJSONKeys. Keys an RC has but that are missing from
+// order follow in the default order (code, message, httpCode, rpcCode, data,
+// originalError, service, suggestion, uuid, caller, tags); keys in order that
+// an RC lacks, or that are unknown, are skipped. Calling it with no keys restores the default.
+// It is safe for concurrent use but is intended to be called once during
+// initialization.
+func SetJSONOrder(order ...string) {
+	order = append([]string(nil), order...)
+	jsonOrder.Store(&order)
+}
+
+// defaultJSONOrder returns the configured key names in field order.
+func defaultJSONOrder() []string {
+	names := currentJSONKeys()
+	return []string{
+		names.Code,
+		names.Message,
+		names.HTTPCode,
+		names.RPCCode,
+		names.Data,
+		names.OriginalError,
+		names.Service,
+		names.Suggestion,
+		names.UUID,
+		names.Caller,
+		names.Tags,
+	}
+}
+
+// OrderedJSON encodes the map returned by JSON(keys...) as a JSON object
+// whose keys follow the order set with SetJSONOrder.
+func (r *RC) OrderedJSON(keys ...string) ([]byte, error) {
+	fields := r.JSON(keys...)
+
+	var order []string
+	if configured := jsonOrder.Load(); configured != nil {
+		order = *configured
+	}
+	order = append(order, defaultJSONOrder()...)
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	written := make(map[string]bool, len(fields))
+	for _, key := range order {
+		value, ok := fields[key]
+		if !ok || written[key] {
+			continue
+		}
+		if len(written) > 0 {
+			buf.WriteByte(',')
+		}
+		written[key] = true
+
+		name, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(data)
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
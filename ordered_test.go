@@ -0,0 +1,75 @@
+package rescode
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+)
+
+func TestRC_OrderedJSON(t *testing.T) {
+	rc := New(1601, 404, codes.NotFound, "Policy not found")(errors.New("no rows"))
+	rc.WithSuggestion("Check the ID")
+
+	data, err := rc.OrderedJSON()
+	if err != nil {
+		t.Fatalf("Failed to encode: %v", err)
+	}
+	expected := `{"code":1601,"message":"Policy not found","httpCode":404,"rpcCode":5,"originalError":"no rows","suggestion":"Check the ID"}`
+	if string(data) != expected {
+		t.Errorf("Expected default order %s, got %s", expected, data)
+	}
+}
+
+func TestSetJSONOrder(t *testing.T) {
+	t.Cleanup(func() {
+		SetJSONOrder()
+		SetJSONKeys(DefaultJSONKeys())
+	})
+
+	SetJSONKeys(JSONKeys{Code: "error_code"})
+	SetJSONOrder("suggestion", "message", "unknown", "data", "error_code")
+
+	rc := New(1601, 404, codes.NotFound, "Policy not found")()
+	rc.WithSuggestion("Check the ID")
+
+	data, err := rc.OrderedJSON()
+	if err != nil {
+		t.Fatalf("Failed to encode: %v", err)
+	}
+	// Absent (data) and unknown keys are skipped; unlisted keys follow in default order
+	expected := `{"suggestion":"Check the ID","message":"Policy not found","error_code":1601,"httpCode":404,"rpcCode":5}`
+	if string(data) != expected {
+		t.Errorf("Expected %s, got %s", expected, data)
+	}
+
+	filtered, err := rc.OrderedJSON("error_code", "message")
+	if err != nil {
+		t.Fatalf("Failed to encode: %v", err)
+	}
+	if string(filtered) != `{"message":"Policy not found","error_code":1601}` {
+		t.Errorf("Expected filtered keys in configured order, got %s", filtered)
+	}
+}
+
+func TestMarshalJSON_Ordered(t *testing.T) {
+	t.Cleanup(func() {
+		OrderedMarshalJSON = false
+		SetJSONOrder()
+	})
+
+	OrderedMarshalJSON = true
+	SetJSONOrder("message", "code")
+
+	rc := New(1601, 404, codes.NotFound, "Policy not found")()
+	data, err := json.Marshal(rc)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+
+	expected := `{"message":"Policy not found","code":1601,"httpCode":404,"rpcCode":5}`
+	if string(data) != expected {
+		t.Errorf("Expected %s, got %s", expected, data)
+	}
+}
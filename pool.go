@@ -0,0 +1,56 @@
+package rescode
+
+import (
+	"sync"
+
+	"google.golang.org/grpc/codes"
+)
+
+var rcPool = sync.Pool{New: func() any { return new(RC) }}
+
+// Acquire returns a zeroed RC from a pool shared by the package, for hot
+// paths that create many short-lived errors. Return it with Release once it
+// has been handled, for example after the response is written.
+func Acquire() *RC {
+	return rcPool.Get().(*RC)
+}
+
+// Release zeroes r and returns it to the pool used by Acquire and
+// NewPooled. The RC and anything still referring to it, including errors
+// that wrap it, must not be used after Release: a later Acquire may hand
+// the same RC out again. Releasing an RC twice corrupts the pool. Release
+// panics on the read-only RC from NewShared.
+func (r *RC) Release() {
+	r.mustBeMutable("Release")
+	*r = RC{}
+	rcPool.Put(r)
+}
+
+// NewPooled is like New but the creator takes its RCs from the pool, so
+// callers that Release every error they create avoid allocating one per
+// call. RCs that are never released are simply collected as usual.
+func NewPooled(code uint64, hCode int, rCode codes.Code, message string, data ...any) RcCreator {
+	var d any
+	if len(data) > 0 {
+		d = data[0]
+	}
+
+	return func(errs ...error) *RC {
+		rc := Acquire()
+		rc.Code = code
+		rc.Message = message
+		rc.HttpCode = hCode
+		rc.RpcCode = rCode
+		rc.Data = d
+
+		if len(errs) > 0 {
+			rc.err = errs[0]
+		}
+
+		if callerEnabled.Load() {
+			rc.caller = captureCaller(1)
+		}
+
+		return rc
+	}
+}
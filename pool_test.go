@@ -0,0 +1,56 @@
+package rescode
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+)
+
+func TestNewPooled(t *testing.T) {
+	create := NewPooled(20001, 404, codes.NotFound, "Policy not found", "p1")
+	cause := errors.New("no rows")
+
+	rc := create(cause)
+	expected := New(20001, 404, codes.NotFound, "Policy not found", "p1")(cause)
+	if !rc.Equal(expected) {
+		t.Errorf("Expected pooled RC to equal its New counterpart, got %v", rc)
+	}
+	rc.Release()
+}
+
+func TestRC_Release_Zeroes(t *testing.T) {
+	rc := Acquire()
+	rc.Code, rc.Message, rc.HttpCode = 20001, "Policy not found", 404
+	rc.WrapWith(errors.New("cause")).WithTag("tenant", "acme")
+
+	rc.Release()
+
+	if rc.Code != 0 || rc.Message != "" || rc.OriginalError() != nil || rc.Tags != nil {
+		t.Errorf("Expected a released RC to be zeroed, got %+v", rc)
+	}
+}
+
+func TestNewPooled_Concurrent(t *testing.T) {
+	create := NewPooled(20001, 404, codes.NotFound, "Policy not found")
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				rc := create(fmt.Errorf("cause %d-%d", g, i))
+				rc.SetData(i)
+				if rc.Code != 20001 || rc.Data != i || rc.OriginalError().Error() != fmt.Sprintf("cause %d-%d", g, i) {
+					t.Errorf("Expected an RC owned by this goroutine, got %v", rc)
+					return
+				}
+				rc.Release()
+			}
+		}(g)
+	}
+	wg.Wait()
+}
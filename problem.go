@@ -0,0 +1,61 @@
+package rescode
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ProblemContentType is the media type of RFC 7807 problem details.
+const ProblemContentType = "application/problem+json"
+
+// ProblemJSON returns an RFC 7807 problem details representation of the
+// error. As "type" is "about:blank", "title" is the HTTP status phrase, as
+// the RFC requires; the message goes in "detail", "status" is the HTTP code
+// and the extension member "code" the error code. The wrapped error is never
+// included.
+func (r *RC) ProblemJSON() map[string]interface{} {
+	return map[string]interface{}{
+		"type":   "about:blank",
+		"title":  http.StatusText(r.HttpCode),
+		"status": r.HttpCode,
+		"detail": r.Message,
+		"code":   r.Code,
+	}
+}
+
+// ProblemJSONWithBase returns an RFC 7807 problem details representation of
+// the error whose "type" member is typeBase followed by "/" and the error
+// code, giving each code a stable type URI that the message can title. The
+// "detail" member is the message, as with ProblemJSON, and the wrapped error
+// is never included. The "instance" member is omitted when instance is empty.
+func (r *RC) ProblemJSONWithBase(typeBase, instance string) map[string]interface{} {
+	problem := map[string]interface{}{
+		"type":   strings.TrimSuffix(typeBase, "/") + "/" + strconv.FormatUint(r.Code, 10),
+		"title":  r.Message,
+		"status": r.HttpCode,
+		"detail": r.Message,
+		"code":   r.Code,
+	}
+
+	if instance != "" {
+		problem["instance"] = instance
+	}
+
+	return problem
+}
+
+// WriteProblem writes ProblemJSON as an application/problem+json response
+// with HttpCode as the status.
+func (r *RC) WriteProblem(w http.ResponseWriter) error {
+	body, err := json.Marshal(r.ProblemJSON())
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(r.HttpCode)
+	_, err = w.Write(body)
+	return err
+}
@@ -0,0 +1,101 @@
+package rescode
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+)
+
+func TestRC_ProblemJSONWithBase(t *testing.T) {
+	rc := New(20001, 404, codes.NotFound, "Policy not found")(errors.New("no rows"))
+
+	problem := rc.ProblemJSONWithBase("https://errors.example.com/", "/policies/42")
+
+	expected := map[string]interface{}{
+		"type":     "https://errors.example.com/20001",
+		"title":    "Policy not found",
+		"status":   404,
+		"detail":   "Policy not found",
+		"instance": "/policies/42",
+		"code":     uint64(20001),
+	}
+	for key, want := range expected {
+		if problem[key] != want {
+			t.Errorf("Expected %s %v, got %v", key, want, problem[key])
+		}
+	}
+	if len(problem) != len(expected) {
+		t.Errorf("Expected %d members, got %d", len(expected), len(problem))
+	}
+}
+
+func TestRC_ProblemJSONWithBase_NoInstance(t *testing.T) {
+	rc := New(20002, 400, codes.InvalidArgument, "Invalid policy kind")()
+
+	problem := rc.ProblemJSONWithBase("https://errors.example.com", "")
+
+	if problem["type"] != "https://errors.example.com/20002" {
+		t.Errorf("Expected type URI https://errors.example.com/20002, got %v", problem["type"])
+	}
+	if _, exists := problem["instance"]; exists {
+		t.Error("Problem should not contain instance when empty")
+	}
+	if problem["detail"] != "Invalid policy kind" {
+		t.Errorf("Expected detail 'Invalid policy kind', got %v", problem["detail"])
+	}
+}
+
+func TestRC_ProblemJSON(t *testing.T) {
+	rc := New(20001, 404, codes.NotFound, "Policy not found")(errors.New("no rows"))
+
+	problem := rc.ProblemJSON()
+
+	expected := map[string]interface{}{
+		"type":   "about:blank",
+		"title":  "Not Found",
+		"status": 404,
+		"detail": "Policy not found",
+		"code":   uint64(20001),
+	}
+	for key, want := range expected {
+		if problem[key] != want {
+			t.Errorf("Expected %s %v, got %v", key, want, problem[key])
+		}
+	}
+	if len(problem) != len(expected) {
+		t.Errorf("Expected %d members, got %d", len(expected), len(problem))
+	}
+
+	if strings.Contains(fmt.Sprint(problem), "no rows") {
+		t.Error("Expected the wrapped error to be left out")
+	}
+}
+
+func TestRC_WriteProblem(t *testing.T) {
+	rc := New(20001, 404, codes.NotFound, "Policy not found")()
+	rec := httptest.NewRecorder()
+
+	if err := rc.WriteProblem(rec); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if rec.Code != 404 {
+		t.Errorf("Expected status 404, got %d", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/problem+json" {
+		t.Errorf("Expected Content-Type application/problem+json, got %q", ct)
+	}
+
+	var body map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Failed to decode body: %v", err)
+	}
+	if body["status"] != float64(404) || body["title"] != "Not Found" || body["code"] != float64(20001) {
+		t.Errorf("Unexpected problem body %v", body)
+	}
+}
@@ -0,0 +1,43 @@
+package rescode
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MergeRegistries unions registries mapping codes to their creators, such as
+// those contributed by independent plugins. It returns an error naming every
+// code registered by more than one registry; the inputs are not modified.
+func MergeRegistries(regs ...map[uint64]RcCreator) (map[uint64]RcCreator, error) {
+	merged := make(map[uint64]RcCreator)
+	owner := make(map[uint64]int)
+	conflicts := make(map[uint64]string)
+
+	for i, reg := range regs {
+		for code, creator := range reg {
+			if first, exists := owner[code]; exists {
+				conflicts[code] = fmt.Sprintf("code %d (registries %d and %d)", code, first, i)
+				continue
+			}
+			owner[code] = i
+			merged[code] = creator
+		}
+	}
+
+	if len(conflicts) > 0 {
+		codes := make([]uint64, 0, len(conflicts))
+		for code := range conflicts {
+			codes = append(codes, code)
+		}
+		sort.Slice(codes, func(i, j int) bool { return codes[i] < codes[j] })
+
+		descriptions := make([]string, len(codes))
+		for i, code := range codes {
+			descriptions[i] = conflicts[code]
+		}
+		return nil, fmt.Errorf("rescode: conflicting registrations: %s", strings.Join(descriptions, ", "))
+	}
+
+	return merged, nil
+}
@@ -0,0 +1,89 @@
+// Package registry builds rescode creators at runtime from a definitions
+// file, for programs that ship the file (for example with go:embed) instead
+// of generating code. It is kept apart from the core package so that
+// depending on rescode does not pull in the definition parsers.
+package registry
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/restayway/rescode"
+	"github.com/restayway/rescode/generator"
+	"google.golang.org/grpc/codes"
+)
+
+// Registry holds creators built from error definitions.
+//
+// The RCs it creates match those of generated factories. Get and ByCode add
+// a map lookup to each creation (compare BenchmarkRegistry_PolicyNotFound
+// with BenchmarkGenerated_PolicyNotFound), and a misspelled key is only
+// caught at run time, where generated code fails to compile.
+type Registry struct {
+	byKey  map[string]rescode.RcCreator
+	byCode map[uint64]rescode.RcCreator
+}
+
+// Load parses definitions in the rescodegen input format from r and builds a
+// Registry from them. The formatHint is interpreted as by
+// generator.ParseInputBytes: a filename ("errors.yaml"), an extension
+// (".json") or a format name ("yaml"), with the format auto-detected when
+// empty. Definitions are validated as for code generation, and duplicate keys
+// or codes are rejected.
+func Load(r io.Reader, formatHint string) (*Registry, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("registry: reading definitions: %w", err)
+	}
+	defs, err := generator.ParseInputBytes(data, formatHint)
+	if err != nil {
+		return nil, fmt.Errorf("registry: %w", err)
+	}
+
+	reg := &Registry{
+		byKey:  make(map[string]rescode.RcCreator, len(defs)),
+		byCode: make(map[uint64]rescode.RcCreator, len(defs)),
+	}
+	for _, def := range defs {
+		if _, exists := reg.byKey[def.Key]; exists {
+			return nil, fmt.Errorf("registry: duplicate key %s", def.Key)
+		}
+		if _, exists := reg.byCode[def.Code]; exists {
+			return nil, fmt.Errorf("registry: code %d of key %s is already used", def.Code, def.Key)
+		}
+		creator := definitionCreator(def)
+		reg.byKey[def.Key] = creator
+		reg.byCode[def.Code] = creator
+	}
+	return reg, nil
+}
+
+// definitionCreator builds the creator a generated factory for def would use.
+func definitionCreator(def generator.ErrorDefinition) rescode.RcCreator {
+	var data []any
+	if def.DataExample != nil {
+		data = append(data, def.DataExample)
+	}
+	create := rescode.New(def.Code, def.HTTP, codes.Code(def.GRPC), def.Message, data...)
+	if def.Suggestion == "" && def.UUID == "" {
+		return create
+	}
+
+	return func(errs ...error) *rescode.RC {
+		rc := create(errs...)
+		rc.Suggestion = def.Suggestion
+		rc.UUID = def.UUID
+		return rc
+	}
+}
+
+// Get returns the creator for key, or nil if the registry has no such key.
+func (r *Registry) Get(key string) rescode.RcCreator {
+	return r.byKey[key]
+}
+
+// ByCode returns the creator for code, or nil if the registry has no such
+// code.
+func (r *Registry) ByCode(code uint64) rescode.RcCreator {
+	return r.byCode[code]
+}
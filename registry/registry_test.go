@@ -0,0 +1,101 @@
+package registry
+
+import (
+	_ "embed"
+	"strings"
+	"testing"
+
+	"github.com/restayway/rescode"
+	"google.golang.org/grpc/codes"
+)
+
+//go:embed testdata/errors.yaml
+var embeddedErrors string
+
+func TestLoad(t *testing.T) {
+	reg, err := Load(strings.NewReader(embeddedErrors), "errors.yaml")
+	if err != nil {
+		t.Fatalf("Failed to load registry: %v", err)
+	}
+
+	rc := reg.Get("PolicyNotFound")()
+	if rc.Code != 20001 || rc.HttpCode != 404 || rc.RpcCode != codes.NotFound || rc.Message != "Policy not found" {
+		t.Errorf("Expected PolicyNotFound (20001, 404, NotFound), got %+v", rc)
+	}
+
+	rc = reg.ByCode(20002)()
+	if rc.Message != "Invalid policy kind" {
+		t.Errorf("Expected 'Invalid policy kind', got %q", rc.Message)
+	}
+
+	if reg.Get("Missing") != nil || reg.ByCode(99999) != nil {
+		t.Error("Expected nil creators for unknown keys and codes")
+	}
+}
+
+func TestLoad_Fields(t *testing.T) {
+	input := `[{"code": 1001, "key": "LoginFailed", "message": "Login failed", "http": 401,
+		"suggestion": "Check your credentials", "uuid": "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		"data_example": {"user": "alice"}}]`
+
+	reg, err := Load(strings.NewReader(input), "json")
+	if err != nil {
+		t.Fatalf("Failed to load registry: %v", err)
+	}
+
+	rc := reg.Get("LoginFailed")()
+	if rc.RpcCode != codes.Unauthenticated {
+		t.Errorf("Expected inferred gRPC code Unauthenticated, got %v", rc.RpcCode)
+	}
+	if rc.Suggestion != "Check your credentials" || rc.UUID != "6ba7b810-9dad-11d1-80b4-00c04fd430c8" {
+		t.Errorf("Expected suggestion and uuid to be set, got %q and %q", rc.Suggestion, rc.UUID)
+	}
+	if data, _ := rc.Data.(map[string]any); data["user"] != "alice" {
+		t.Errorf("Expected data example as Data, got %v", rc.Data)
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		match string
+	}{
+		{"invalid", "- code: 0\n  key: Zero\n  message: Zero\n  http: 400\n", "code cannot be 0"},
+		{"duplicate key", "- {code: 1, key: A, message: A, http: 400}\n- {code: 2, key: A, message: B, http: 400}\n", "duplicate key A"},
+		{"duplicate code", "- {code: 1, key: A, message: A, http: 400}\n- {code: 1, key: B, message: B, http: 400}\n", "code 1 of key B"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(strings.NewReader(tt.input), "yaml")
+			if err == nil || !strings.Contains(err.Error(), tt.match) {
+				t.Errorf("Expected error containing %q, got %v", tt.match, err)
+			}
+		})
+	}
+}
+
+// benchmarkSink keeps results alive so the compiler cannot elide them.
+var benchmarkSink *rescode.RC
+
+func BenchmarkGenerated_PolicyNotFound(b *testing.B) {
+	create := rescode.New(20001, 404, codes.NotFound, "Policy not found")
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		benchmarkSink = create()
+	}
+}
+
+func BenchmarkRegistry_PolicyNotFound(b *testing.B) {
+	reg, err := Load(strings.NewReader("- {code: 20001, key: PolicyNotFound, message: Policy not found, http: 404}\n"), "yaml")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		benchmarkSink = reg.Get("PolicyNotFound")()
+	}
+}
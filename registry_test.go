@@ -0,0 +1,50 @@
+package rescode
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+)
+
+func TestMergeRegistries(t *testing.T) {
+	auth := map[uint64]RcCreator{
+		1001: New(1001, 401, codes.Unauthenticated, "Login failed"),
+	}
+	policy := map[uint64]RcCreator{
+		2001: New(2001, 404, codes.NotFound, "Policy not found"),
+		2002: New(2002, 400, codes.InvalidArgument, "Invalid policy kind"),
+	}
+
+	merged, err := MergeRegistries(auth, policy)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(merged) != 3 {
+		t.Fatalf("Expected 3 entries, got %d", len(merged))
+	}
+	if rc := merged[2001](); rc.Message != "Policy not found" {
+		t.Errorf("Expected 'Policy not found', got %s", rc.Message)
+	}
+
+	if merged, err := MergeRegistries(); err != nil || len(merged) != 0 {
+		t.Errorf("Expected an empty merge, got %v, %v", merged, err)
+	}
+}
+
+func TestMergeRegistries_Collision(t *testing.T) {
+	a := map[uint64]RcCreator{1001: New(1001, 401, codes.Unauthenticated, "Login failed")}
+	b := map[uint64]RcCreator{2001: New(2001, 404, codes.NotFound, "Policy not found")}
+	c := map[uint64]RcCreator{1001: New(1001, 400, codes.InvalidArgument, "Bad login")}
+
+	merged, err := MergeRegistries(a, b, c)
+	if err == nil {
+		t.Fatal("Expected a collision error")
+	}
+	if merged != nil {
+		t.Error("Expected nil result on collision")
+	}
+	if !strings.Contains(err.Error(), "code 1001 (registries 0 and 2)") {
+		t.Errorf("Expected error to name code 1001, got %v", err)
+	}
+}
@@ -2,22 +2,40 @@
 package rescode
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"reflect"
 	"strings"
+	"sync"
 
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // RC represents a structured error with multiple code formats and optional data.
 type RC struct {
-	Code     uint64     // Unique error code
-	Message  string     // Human-readable error message
-	HttpCode int        // HTTP status code
-	RpcCode  codes.Code // gRPC status code
-	Data     any        // Optional additional data
-	err      error      // Wrapped original error
+	Code       uint64            // Unique error code
+	Message    string            // Human-readable error message
+	HttpCode   int               // HTTP status code
+	RpcCode    codes.Code        // gRPC status code
+	Data       any               // Optional additional data
+	Service    string            // Originating service, overriding ServiceName when set
+	Suggestion string            // Optional remediation hint for the caller
+	UUID       string            // Optional stable identifier for external registries
+	Tags       map[string]string // Cross-cutting metadata such as request or tenant IDs, set with WithTag
+	err        error             // Wrapped original error
+	stack      []uintptr         // Program counters captured by RecoverWithStack
+	caller     string            // Creation site recorded when EnableCaller is on
+	shared     bool              // Read-only RC returned by NewShared
+	mu         *sync.RWMutex     // Guards Data, Tags and err for RCs created by NewSync
 }
 
+// ServiceName is the default originating service reported by JSON for errors
+// without a per-error Service. It should be set once at init.
+var ServiceName string
+
 // RcCreator is a function type that creates an RC with optional wrapped errors.
 type RcCreator func(...error) *RC
 
@@ -42,65 +60,344 @@ func New(code uint64, hCode int, rCode codes.Code, message string, data ...any)
 			rc.err = errs[0]
 		}
 
+		if callerEnabled.Load() {
+			rc.caller = captureCaller(1)
+		}
+
 		return rc
 	}
 }
 
+// NewValidated is like New but first checks that code is non-zero, hCode is
+// a valid HTTP status (100-599), rCode is a known gRPC code (0-16) and message
+// is non-empty.
+func NewValidated(code uint64, hCode int, rCode codes.Code, message string, data ...any) (RcCreator, error) {
+	if code == 0 {
+		return nil, errors.New("rescode: code cannot be 0")
+	}
+	if hCode < 100 || hCode > 599 {
+		return nil, fmt.Errorf("rescode: http code %d must be between 100 and 599", hCode)
+	}
+	if rCode > codes.Unauthenticated {
+		return nil, fmt.Errorf("rescode: grpc code %d must be between 0 and 16", rCode)
+	}
+	if message == "" {
+		return nil, errors.New("rescode: message cannot be empty")
+	}
+	return New(code, hCode, rCode, message, data...), nil
+}
+
+// MustNew is like NewValidated but panics on invalid arguments. It is intended
+// for package-level variables:
+//
+//	var ErrPolicyNotFound = rescode.MustNew(20001, 404, codes.NotFound, "Policy not found")
+func MustNew(code uint64, hCode int, rCode codes.Code, message string, data ...any) RcCreator {
+	create, err := NewValidated(code, hCode, rCode, message, data...)
+	if err != nil {
+		panic(err)
+	}
+	return create
+}
+
 // Error implements the error interface.
 func (r *RC) Error() string {
+	defer r.rlock()()
 	if r.err != nil {
 		return r.Message + ": " + r.err.Error()
 	}
 	return r.Message
 }
 
+// Is reports whether target is an RC with the same code, so errors.Is can
+// match an error against a sentinel RC regardless of its cause or data.
+func (r *RC) Is(target error) bool {
+	t, ok := target.(*RC)
+	if !ok || t == nil {
+		return false
+	}
+	return r.Code == t.Code
+}
+
+// GRPCStatus returns the gRPC status for the error, allowing status.FromError
+// and status.Code to recognize an RC directly. The status message is Message;
+// the wrapped error is not sent to clients. The code and Data are attached as
+// a status detail (see StatusDetail) unless the gRPC code is OK or Data cannot
+// be encoded as JSON.
+func (r *RC) GRPCStatus() *status.Status {
+	st := status.New(r.RpcCode, r.Message)
+	detail, err := r.statusDetail()
+	if err != nil {
+		return st
+	}
+	if withDetails, err := st.WithDetails(detail); err == nil {
+		return withDetails
+	}
+	return st
+}
+
+// DefaultDataIsMap makes SetData wrap scalar values (booleans, numbers and
+// strings) as map[string]any{"value": x} so serialized Data always has the
+// same shape. It is off by default and should be set once at init.
+var DefaultDataIsMap bool
+
+// coerceData applies DefaultDataIsMap to data.
+func coerceData(data any) any {
+	if !DefaultDataIsMap || data == nil {
+		return data
+	}
+	switch reflect.ValueOf(data).Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return map[string]any{"value": data}
+	}
+	return data
+}
+
 // SetData sets additional data for the error and returns the RC for chaining.
 func (r *RC) SetData(data any) *RC {
+	r.mustBeMutable("SetData")
+	defer r.lock()()
+	r.Data = coerceData(data)
+	return r
+}
+
+// AppendData adds key to map-valued Data and returns the RC for chaining. Nil
+// Data starts a new map[string]any. Data that is already a map with string
+// keys is copied into a new map[string]any before adding the key, so maps
+// shared with the creator or other RCs are never modified. Any other Data is
+// overwritten by a new map holding only key.
+func (r *RC) AppendData(key string, value any) *RC {
+	r.mustBeMutable("AppendData")
+	defer r.lock()()
+	data := make(map[string]any)
+	if r.Data != nil {
+		v := reflect.ValueOf(r.Data)
+		if v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String {
+			iter := v.MapRange()
+			for iter.Next() {
+				data[iter.Key().String()] = iter.Value().Interface()
+			}
+		}
+	}
+	data[key] = value
 	r.Data = data
 	return r
 }
 
+// WrapWith sets the wrapped original error and returns the RC for chaining.
+// Like SetData it mutates the receiver, so it must not be used on an RC shared
+// between goroutines unless the RC was created by NewSync. Passing nil clears
+// the wrapped error.
+func (r *RC) WrapWith(err error) *RC {
+	r.mustBeMutable("WrapWith")
+	defer r.lock()()
+	r.err = err
+	return r
+}
+
+// DataAs returns the RC's Data asserted to type T, reporting whether the
+// assertion succeeded. It spares callers an explicit type assertion:
+//
+//	d, ok := rescode.DataAs[map[string]string](rc)
+func DataAs[T any](r *RC) (T, bool) {
+	data, _ := r.guarded()
+	d, ok := data.(T)
+	return d, ok
+}
+
 // JSON returns a map representation of the error, optionally filtering by keys.
+// Key names follow the configuration set with SetJSONKeys, and filter keys
+// refer to those configured names.
 func (r *RC) JSON(keys ...string) map[string]interface{} {
-	result := map[string]interface{}{
-		"code":     r.Code,
-		"message":  r.Message,
-		"httpCode": r.HttpCode,
-		"rpcCode":  int(r.RpcCode),
+	defer r.rlock()()
+	names := currentJSONKeys()
+	service := r.serviceName()
+
+	// With a filter, fields that were not requested are never added
+	want := func(name string) bool {
+		return len(keys) == 0 || containsString(keys, name)
 	}
 
-	if r.Data != nil {
-		result["data"] = r.Data
+	// Size the map for the fields present so it never grows
+	size := 4
+	for _, present := range [...]bool{r.Data != nil, r.err != nil, service != "",
+		r.Suggestion != "", r.UUID != "", r.caller != "", len(r.Tags) > 0} {
+		if present {
+			size++
+		}
+	}
+	if len(keys) > 0 && len(keys) < size {
+		size = len(keys)
 	}
 
-	if r.err != nil {
-		result["originalError"] = r.err.Error()
+	result := make(map[string]interface{}, size)
+	if want(names.Code) {
+		result[names.Code] = r.Code
+	}
+	if want(names.Message) {
+		result[names.Message] = r.Message
+	}
+	if want(names.HTTPCode) {
+		result[names.HTTPCode] = r.HttpCode
+	}
+	if want(names.RPCCode) {
+		result[names.RPCCode] = int(r.RpcCode)
 	}
 
-	// If specific keys are requested, filter the result
-	if len(keys) > 0 {
-		filtered := make(map[string]interface{})
-		for _, key := range keys {
-			if val, exists := result[key]; exists {
-				filtered[key] = val
-			}
+	if r.Data != nil && want(names.Data) {
+		result[names.Data] = r.Data
+	}
+
+	if r.err != nil && want(names.OriginalError) {
+		result[names.OriginalError] = formatOriginalError(r.err)
+	}
+
+	if service != "" && want(names.Service) {
+		result[names.Service] = service
+	}
+
+	if r.Suggestion != "" && want(names.Suggestion) {
+		result[names.Suggestion] = r.Suggestion
+	}
+
+	if r.UUID != "" && want(names.UUID) {
+		result[names.UUID] = r.UUID
+	}
+
+	if r.caller != "" && want(names.Caller) {
+		result[names.Caller] = r.caller
+	}
+
+	if len(r.Tags) > 0 && want(names.Tags) {
+		tags := make(map[string]string, len(r.Tags))
+		for k, v := range r.Tags {
+			tags[k] = v
 		}
-		return filtered
+		result[names.Tags] = tags
 	}
 
 	return result
 }
 
+// containsString reports whether list contains s.
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// JSONEnvelope returns JSON(keys...) nested under an "error" key, the
+// {"error": {...}} shape many API contracts use for error bodies.
+func (r *RC) JSONEnvelope(keys ...string) map[string]interface{} {
+	return map[string]interface{}{"error": r.JSON(keys...)}
+}
+
+// WithService sets the originating service for this error, overriding
+// ServiceName, and returns the RC for chaining.
+func (r *RC) WithService(name string) *RC {
+	r.mustBeMutable("WithService")
+	r.Service = name
+	return r
+}
+
+// WithSuggestion sets a human-friendly remediation hint, such as "Check the
+// policy ID and try again", and returns the RC for chaining.
+func (r *RC) WithSuggestion(s string) *RC {
+	r.mustBeMutable("WithSuggestion")
+	r.Suggestion = s
+	return r
+}
+
+// WithTag sets the tag k to v and returns the RC for chaining. Tags carry
+// cross-cutting metadata such as a request or tenant ID, leaving Data free
+// for the domain payload.
+func (r *RC) WithTag(k, v string) *RC {
+	r.mustBeMutable("WithTag")
+	defer r.lock()()
+	if r.Tags == nil {
+		r.Tags = make(map[string]string)
+	}
+	r.Tags[k] = v
+	return r
+}
+
+// WithUUID sets a stable identifier used by incident systems that key on
+// UUIDs rather than numeric codes, and returns the RC for chaining.
+func (r *RC) WithUUID(id string) *RC {
+	r.mustBeMutable("WithUUID")
+	r.UUID = id
+	return r
+}
+
+// serviceName returns the per-error service, falling back to ServiceName.
+func (r *RC) serviceName() string {
+	if r.Service != "" {
+		return r.Service
+	}
+	return ServiceName
+}
+
+// MarshalJSON implements json.Marshaler using the map returned by JSON, or
+// OrderedJSON when OrderedMarshalJSON is set.
+func (r *RC) MarshalJSON() ([]byte, error) {
+	if OrderedMarshalJSON {
+		return r.OrderedJSON()
+	}
+	return json.Marshal(r.JSON())
+}
+
+// Equal reports whether r and other are semantically equal: same Code,
+// Message, HttpCode and RpcCode, deeply equal Data, and wrapped errors with
+// the same Error() text. Two nil RCs are equal; a nil and a non-nil RC are not.
+func (r *RC) Equal(other *RC) bool {
+	if r == nil || other == nil || r == other {
+		return r == other
+	}
+	if r.Code != other.Code || r.Message != other.Message ||
+		r.HttpCode != other.HttpCode || r.RpcCode != other.RpcCode {
+		return false
+	}
+
+	// Read each side under its own lock in turn, never holding both.
+	data, err := r.guarded()
+	otherData, otherErr := other.guarded()
+	if !reflect.DeepEqual(data, otherData) {
+		return false
+	}
+	if (err == nil) != (otherErr == nil) {
+		return false
+	}
+	return err == nil || err.Error() == otherErr.Error()
+}
+
 // OriginalError returns the wrapped original error, if any.
 func (r *RC) OriginalError() error {
+	defer r.rlock()()
 	return r.err
 }
 
+// Unwrap returns the wrapped original error, so errors.Is and errors.As reach
+// causes beneath an RC, including other RCs. Unlike OriginalErrorChain they
+// do not stop at cycles; see HasCycle.
+func (r *RC) Unwrap() error {
+	return r.OriginalError()
+}
+
 // String returns a string representation of the error.
 func (r *RC) String() string {
+	defer r.rlock()()
 	var parts []string
 	parts = append(parts, fmt.Sprintf("Code:%d", r.Code))
-	parts = append(parts, fmt.Sprintf("HTTP:%d", r.HttpCode))
+	if text := r.HTTPStatusText(); text != "" {
+		parts = append(parts, fmt.Sprintf("HTTP:%d (%s)", r.HttpCode, text))
+	} else {
+		parts = append(parts, fmt.Sprintf("HTTP:%d", r.HttpCode))
+	}
 	parts = append(parts, fmt.Sprintf("gRPC:%d", r.RpcCode))
 	parts = append(parts, fmt.Sprintf("Message:%s", r.Message))
 
@@ -112,5 +409,100 @@ func (r *RC) String() string {
 		parts = append(parts, fmt.Sprintf("OriginalError:%v", r.err))
 	}
 
+	if r.caller != "" {
+		parts = append(parts, fmt.Sprintf("Caller:%s", r.caller))
+	}
+
 	return fmt.Sprintf("RC{%s}", strings.Join(parts, ", "))
 }
+
+// summaryMaxLen is the maximum length, in runes, of a Summary.
+const summaryMaxLen = 120
+
+// Summary returns a condensed one-line description suitable for alert titles,
+// such as "[20001 NotFound] Policy not found". Summaries longer than 120 runes
+// are truncated and end in "...".
+func (r *RC) Summary() string {
+	s := fmt.Sprintf("[%d %s] %s", r.Code, r.RPCCodeName(), strings.Join(strings.Fields(r.Message), " "))
+	runes := []rune(s)
+	if len(runes) <= summaryMaxLen {
+		return s
+	}
+	return string(runes[:summaryMaxLen-3]) + "..."
+}
+
+// HTTPStatusText returns the standard text for HttpCode, such as "Not Found",
+// or "" if the code is unknown.
+func (r *RC) HTTPStatusText() string {
+	return http.StatusText(r.HttpCode)
+}
+
+// RPCCodeName returns the name of RpcCode, such as "NotFound".
+func (r *RC) RPCCodeName() string {
+	return r.RpcCode.String()
+}
+
+// clone returns a shallow copy of the RC.
+func (r *RC) clone() *RC {
+	unlock := r.rlock()
+	c := *r
+	if r.Tags != nil {
+		c.Tags = make(map[string]string, len(r.Tags))
+		for k, v := range r.Tags {
+			c.Tags[k] = v
+		}
+	}
+	unlock()
+	if c.mu != nil {
+		c.mu = new(sync.RWMutex)
+	}
+	c.shared = false
+	return &c
+}
+
+// Public returns a copy of the RC without the wrapped error, safe to return
+// to external clients: its JSON never contains originalError. The receiver
+// keeps the cause for internal logging. Since the result is a copy, Data can
+// also be dropped with Public().SetData(nil) without affecting the original.
+func (r *RC) Public() *RC {
+	c := r.clone()
+	c.err = nil
+	return c
+}
+
+// NormalizeCause returns a copy of the RC whose wrapped error, if it is not
+// already an RC, is replaced by the RC produced by classify. The receiver is
+// left untouched. If classify is nil, returns nil, or there is no cause, the
+// receiver is returned as-is.
+func (r *RC) NormalizeCause(classify func(error) *RC) *RC {
+	_, cause := r.guarded()
+	if classify == nil || cause == nil {
+		return r
+	}
+	if _, ok := cause.(*RC); ok {
+		return r
+	}
+
+	normalized := classify(cause)
+	if normalized == nil {
+		return r
+	}
+
+	c := r.clone()
+	c.err = normalized
+	return c
+}
+
+// IsAnyCode reports whether err is, or wraps, an RC whose code is one of codes.
+func IsAnyCode(err error, codes ...uint64) bool {
+	var rc *RC
+	if !errors.As(err, &rc) {
+		return false
+	}
+	for _, code := range codes {
+		if rc.Code == code {
+			return true
+		}
+	}
+	return false
+}
@@ -2,9 +2,13 @@ package rescode
 
 import (
 	"errors"
+	"fmt"
+	"reflect"
+	"strings"
 	"testing"
 
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestRC_Basic(t *testing.T) {
@@ -112,6 +116,32 @@ func TestRC_SetData(t *testing.T) {
 	}
 }
 
+func TestRC_SetData_DefaultDataIsMap(t *testing.T) {
+	t.Cleanup(func() { DefaultDataIsMap = false })
+
+	rc := New(1017, 400, codes.InvalidArgument, "test error")()
+
+	rc.SetData(42)
+	if rc.Data != 42 {
+		t.Errorf("Expected scalar Data to be stored as-is when flag is off, got %v", rc.Data)
+	}
+
+	DefaultDataIsMap = true
+
+	rc.SetData(42)
+	if dataMap, ok := rc.Data.(map[string]any); !ok {
+		t.Errorf("Expected scalar Data to be wrapped in a map, got %T", rc.Data)
+	} else if dataMap["value"] != 42 {
+		t.Errorf("Expected Data['value'] to be 42, got %v", dataMap["value"])
+	}
+
+	existing := map[string]any{"field": "name"}
+	rc.SetData(existing)
+	if dataMap, ok := rc.Data.(map[string]any); !ok || dataMap["field"] != "name" {
+		t.Errorf("Expected map Data to be stored as-is, got %v", rc.Data)
+	}
+}
+
 func TestRC_JSON(t *testing.T) {
 	testData := map[string]interface{}{"test": "data"}
 	originalErr := errors.New("wrapped error")
@@ -149,6 +179,27 @@ func TestRC_JSON(t *testing.T) {
 	}
 }
 
+func TestRC_JSON_Service(t *testing.T) {
+	t.Cleanup(func() { ServiceName = "" })
+
+	rc := New(1018, 500, codes.Internal, "internal error")()
+	if _, exists := rc.JSON()["service"]; exists {
+		t.Error("JSON should not contain service when none is set")
+	}
+
+	ServiceName = "policy-service"
+	if rc.JSON()["service"] != "policy-service" {
+		t.Errorf("Expected global service 'policy-service', got %v", rc.JSON()["service"])
+	}
+
+	if rc.WithService("billing-service") != rc {
+		t.Error("WithService should return the same RC instance for chaining")
+	}
+	if rc.JSON()["service"] != "billing-service" {
+		t.Errorf("Expected per-error service 'billing-service', got %v", rc.JSON()["service"])
+	}
+}
+
 func TestRC_JSON_FilteredKeys(t *testing.T) {
 	creator := New(1006, 400, codes.InvalidArgument, "test message")
 	rc := creator()
@@ -172,6 +223,95 @@ func TestRC_JSON_FilteredKeys(t *testing.T) {
 	}
 }
 
+func TestRC_JSONEnvelope(t *testing.T) {
+	rc := New(1007, 404, codes.NotFound, "not found")()
+
+	envelope := rc.JSONEnvelope("code", "message")
+	if len(envelope) != 1 {
+		t.Errorf("Expected envelope to have 1 key, got %d", len(envelope))
+	}
+
+	inner, ok := envelope["error"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected error to hold a map, got %T", envelope["error"])
+	}
+	if len(inner) != 2 {
+		t.Errorf("Expected filtered error to have 2 keys, got %d", len(inner))
+	}
+	if inner["code"] != uint64(1007) {
+		t.Errorf("Expected code 1007, got %v", inner["code"])
+	}
+	if _, exists := inner["httpCode"]; exists {
+		t.Error("Envelope should not contain httpCode when filtered")
+	}
+
+	if full := rc.JSONEnvelope()["error"].(map[string]interface{}); full["httpCode"] != 404 {
+		t.Errorf("Expected unfiltered httpCode 404, got %v", full["httpCode"])
+	}
+}
+
+func TestRC_Equal(t *testing.T) {
+	creator := New(1020, 404, codes.NotFound, "not found", map[string]string{"id": "42"})
+
+	tests := []struct {
+		name     string
+		a        *RC
+		b        *RC
+		expected bool
+	}{
+		{
+			name:     "equal with distinct wrapped errors",
+			a:        creator(errors.New("no rows")),
+			b:        creator(errors.New("no rows")),
+			expected: true,
+		},
+		{
+			name:     "differing code",
+			a:        creator(),
+			b:        New(1021, 404, codes.NotFound, "not found", map[string]string{"id": "42"})(),
+			expected: false,
+		},
+		{
+			name:     "differing wrapped error",
+			a:        creator(errors.New("no rows")),
+			b:        creator(errors.New("timeout")),
+			expected: false,
+		},
+		{
+			name:     "wrapped error on one side only",
+			a:        creator(errors.New("no rows")),
+			b:        creator(),
+			expected: false,
+		},
+		{
+			name:     "differing data",
+			a:        creator(),
+			b:        creator().SetData(map[string]string{"id": "43"}),
+			expected: false,
+		},
+		{
+			name:     "nil argument",
+			a:        creator(),
+			b:        nil,
+			expected: false,
+		},
+		{
+			name:     "both nil",
+			a:        nil,
+			b:        nil,
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.Equal(tt.b); got != tt.expected {
+				t.Errorf("Expected Equal() %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
 func TestRC_String(t *testing.T) {
 	testData := "test data"
 	originalErr := errors.New("wrapped error")
@@ -226,6 +366,403 @@ func TestRC_String_Minimal(t *testing.T) {
 	}
 }
 
+func TestRC_Public(t *testing.T) {
+	cause := errors.New("dial tcp db.internal:5432: connection refused")
+	rc := New(1019, 500, codes.Internal, "internal error", "details")(cause)
+
+	public := rc.Public()
+
+	if public == rc {
+		t.Fatal("Public should return a copy")
+	}
+	if public.OriginalError() != nil {
+		t.Errorf("Expected Public().OriginalError() to be nil, got %v", public.OriginalError())
+	}
+	if _, exists := public.JSON()["originalError"]; exists {
+		t.Error("Public().JSON() should not contain originalError")
+	}
+	if public.Data != "details" {
+		t.Errorf("Expected Public() to keep Data, got %v", public.Data)
+	}
+	if rc.OriginalError() != cause {
+		t.Errorf("Expected original RC to keep its cause, got %v", rc.OriginalError())
+	}
+
+	if rc.Public().SetData(nil); rc.Data != "details" {
+		t.Error("Dropping Data from the public copy should not affect the original")
+	}
+}
+
+func TestRC_NormalizeCause(t *testing.T) {
+	cause := errors.New("connection refused")
+	rc := New(1009, 500, codes.Internal, "internal error")(cause)
+	unavailable := New(1010, 503, codes.Unavailable, "service unavailable")
+
+	normalized := rc.NormalizeCause(func(err error) *RC {
+		return unavailable(err)
+	})
+
+	if normalized == rc {
+		t.Fatal("NormalizeCause should return a copy when the cause is replaced")
+	}
+	inner, ok := normalized.OriginalError().(*RC)
+	if !ok {
+		t.Fatalf("Expected normalized cause to be *RC, got %T", normalized.OriginalError())
+	}
+	if inner.Code != 1010 {
+		t.Errorf("Expected normalized cause code 1010, got %d", inner.Code)
+	}
+	if inner.OriginalError() != cause {
+		t.Errorf("Expected normalized cause to wrap %v, got %v", cause, inner.OriginalError())
+	}
+	if rc.OriginalError() != cause {
+		t.Error("NormalizeCause should not modify the original RC")
+	}
+}
+
+func TestRC_NormalizeCause_NilClassifier(t *testing.T) {
+	cause := errors.New("connection refused")
+	rc := New(1009, 500, codes.Internal, "internal error")(cause)
+
+	if rc.NormalizeCause(nil) != rc {
+		t.Error("NormalizeCause with nil classifier should return the same RC")
+	}
+	if rc.OriginalError() != cause {
+		t.Errorf("Expected cause %v to be preserved, got %v", cause, rc.OriginalError())
+	}
+}
+
+func TestIsAnyCode(t *testing.T) {
+	rc := New(1011, 404, codes.NotFound, "not found")()
+
+	tests := []struct {
+		name     string
+		err      error
+		codes    []uint64
+		expected bool
+	}{
+		{name: "matching code", err: rc, codes: []uint64{1000, 1011}, expected: true},
+		{name: "matching wrapped code", err: fmt.Errorf("lookup: %w", rc), codes: []uint64{1011}, expected: true},
+		{name: "non-matching code", err: rc, codes: []uint64{1000, 1012}, expected: false},
+		{name: "non-RC error", err: errors.New("plain error"), codes: []uint64{1011}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsAnyCode(tt.err, tt.codes...); got != tt.expected {
+				t.Errorf("Expected IsAnyCode() %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRC_Is(t *testing.T) {
+	notFound := New(1015, 404, codes.NotFound, "not found")
+	sentinel := notFound()
+
+	wrapped := fmt.Errorf("loading policy: %w", notFound(errors.New("no rows")))
+	if !errors.Is(wrapped, sentinel) {
+		t.Error("Expected wrapped RC to match sentinel with the same code")
+	}
+
+	other := New(1016, 400, codes.InvalidArgument, "invalid")()
+	if errors.Is(wrapped, other) {
+		t.Error("Expected RC not to match sentinel with a different code")
+	}
+	if errors.Is(sentinel, errors.New("not found")) {
+		t.Error("Expected RC not to match a non-RC error")
+	}
+}
+
+func TestRC_GRPCStatus(t *testing.T) {
+	rc := New(1012, 404, codes.NotFound, "not found")(errors.New("no rows"))
+
+	st, ok := status.FromError(rc)
+	if !ok {
+		t.Fatal("Expected status.FromError to recognize RC")
+	}
+	if st.Code() != codes.NotFound {
+		t.Errorf("Expected status code NotFound, got %v", st.Code())
+	}
+	if st.Message() != "not found" {
+		t.Errorf("Expected status message 'not found' without the cause, got %q", st.Message())
+	}
+}
+
+func TestRC_WrapWith(t *testing.T) {
+	rc := New(1014, 500, codes.Internal, "internal error")()
+	cause := errors.New("disk full")
+
+	result := rc.WrapWith(cause)
+	if result != rc {
+		t.Error("WrapWith should return the same RC instance for chaining")
+	}
+	if rc.OriginalError() != cause {
+		t.Errorf("Expected OriginalError() %v, got %v", cause, rc.OriginalError())
+	}
+
+	replacement := errors.New("disk quota exceeded")
+	if rc.WrapWith(replacement).SetData("volume-1").OriginalError() != replacement {
+		t.Error("WrapWith should replace the wrapped error when chained")
+	}
+
+	rc.WrapWith(nil)
+	if rc.OriginalError() != nil {
+		t.Errorf("Expected WrapWith(nil) to clear the wrapped error, got %v", rc.OriginalError())
+	}
+	if rc.Error() != "internal error" {
+		t.Errorf("Expected Error() 'internal error' after clearing, got %q", rc.Error())
+	}
+}
+
+func TestDataAs(t *testing.T) {
+	rc := New(1013, 400, codes.InvalidArgument, "invalid", map[string]string{"field": "name"})()
+
+	data, ok := DataAs[map[string]string](rc)
+	if !ok {
+		t.Fatal("Expected DataAs to succeed for matching type")
+	}
+	if data["field"] != "name" {
+		t.Errorf("Expected data['field'] to be 'name', got %v", data["field"])
+	}
+
+	if str, ok := DataAs[string](rc); ok {
+		t.Errorf("Expected DataAs to fail for mismatching type, got %q", str)
+	}
+}
+
+func TestRC_AppendData(t *testing.T) {
+	t.Run("nil data", func(t *testing.T) {
+		rc := New(1001, 400, codes.InvalidArgument, "Invalid")()
+		rc.AppendData("field", "email").AppendData("reason", "format")
+
+		data, ok := rc.Data.(map[string]any)
+		if !ok {
+			t.Fatalf("Expected map[string]any, got %T", rc.Data)
+		}
+		if data["field"] != "email" || data["reason"] != "format" {
+			t.Errorf("Expected both keys, got %v", data)
+		}
+	})
+
+	t.Run("existing map", func(t *testing.T) {
+		shared := map[string]string{"field": "email"}
+		create := New(1001, 400, codes.InvalidArgument, "Invalid", shared)
+
+		rc := create().AppendData("reason", "format")
+
+		data := rc.Data.(map[string]any)
+		if data["field"] != "email" || data["reason"] != "format" {
+			t.Errorf("Expected existing and new keys, got %v", data)
+		}
+		if _, ok := shared["reason"]; ok {
+			t.Error("AppendData should not modify the creator's data")
+		}
+		if other := create(); other.Data.(map[string]string)["reason"] != "" {
+			t.Error("AppendData should not leak into other RCs")
+		}
+	})
+
+	t.Run("non-map data", func(t *testing.T) {
+		rc := New(1001, 400, codes.InvalidArgument, "Invalid", "scalar")()
+		rc.AppendData("field", "email")
+
+		data := rc.Data.(map[string]any)
+		if len(data) != 1 || data["field"] != "email" {
+			t.Errorf("Expected non-map data to be overwritten, got %v", data)
+		}
+	})
+}
+
+func TestRC_HTTPStatusText(t *testing.T) {
+	tests := []struct {
+		httpCode int
+		expected string
+	}{
+		{404, "Not Found"},
+		{500, "Internal Server Error"},
+		{599, ""},
+	}
+
+	for _, tt := range tests {
+		rc := New(1001, tt.httpCode, codes.Unknown, "Test")()
+		if got := rc.HTTPStatusText(); got != tt.expected {
+			t.Errorf("Expected %q for HTTP %d, got %q", tt.expected, tt.httpCode, got)
+		}
+	}
+}
+
+func TestRC_RPCCodeName(t *testing.T) {
+	rc := New(1001, 404, codes.NotFound, "Test")()
+	if got := rc.RPCCodeName(); got != "NotFound" {
+		t.Errorf("Expected NotFound, got %s", got)
+	}
+}
+
+func TestRC_String_HTTPStatusText(t *testing.T) {
+	rc := New(1001, 404, codes.NotFound, "Test")()
+	if !contains(rc.String(), "HTTP:404 (Not Found)") {
+		t.Errorf("Expected String to include the HTTP status text, got %s", rc.String())
+	}
+
+	rc = New(1001, 599, codes.Unknown, "Test")()
+	if !contains(rc.String(), "HTTP:599,") {
+		t.Errorf("Expected String to omit text for an unknown HTTP code, got %s", rc.String())
+	}
+}
+
+func TestRC_WithSuggestion(t *testing.T) {
+	rc := New(1001, 404, codes.NotFound, "Policy not found")()
+
+	if _, ok := rc.JSON()["suggestion"]; ok {
+		t.Error("JSON should omit suggestion when empty")
+	}
+
+	if rc.WithSuggestion("Check the policy ID and try again") != rc {
+		t.Error("WithSuggestion should return the same RC instance for chaining")
+	}
+	if got := rc.JSON()["suggestion"]; got != "Check the policy ID and try again" {
+		t.Errorf("Expected suggestion in JSON, got %v", got)
+	}
+	if got := rc.Response().Suggestion; got != "Check the policy ID and try again" {
+		t.Errorf("Expected suggestion in Response, got %q", got)
+	}
+}
+
+func TestRC_WithUUID(t *testing.T) {
+	rc := New(1001, 404, codes.NotFound, "Policy not found")()
+
+	if _, ok := rc.JSON()["uuid"]; ok {
+		t.Error("JSON should omit uuid when empty")
+	}
+
+	id := "6f1c7d1e-8a4b-5c3d-9e2f-0a1b2c3d4e5f"
+	if rc.WithUUID(id) != rc {
+		t.Error("WithUUID should return the same RC instance for chaining")
+	}
+	if got := rc.JSON()["uuid"]; got != id {
+		t.Errorf("Expected uuid in JSON, got %v", got)
+	}
+
+	decoded, err := FromJSON(rc.JSON())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if decoded.UUID != id {
+		t.Errorf("Expected uuid to round-trip, got %q", decoded.UUID)
+	}
+}
+
+func TestRC_WithTag(t *testing.T) {
+	rc := New(1001, 404, codes.NotFound, "Policy not found", "payload")()
+
+	if _, ok := rc.JSON()["tags"]; ok {
+		t.Error("JSON should omit tags when empty")
+	}
+
+	if rc.WithTag("requestId", "req-1").WithTag("tenant", "acme") != rc {
+		t.Error("WithTag should return the same RC instance for chaining")
+	}
+	rc.WithTag("tenant", "globex")
+
+	expected := map[string]string{"requestId": "req-1", "tenant": "globex"}
+	if !reflect.DeepEqual(rc.Tags, expected) {
+		t.Errorf("Expected tags %v, got %v", expected, rc.Tags)
+	}
+	if got := rc.JSON()["tags"]; !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected tags %v in JSON, got %v", expected, got)
+	}
+	if rc.Data != "payload" {
+		t.Errorf("Expected Data to be untouched, got %v", rc.Data)
+	}
+
+	public := rc.Public().WithTag("tenant", "initech")
+	if rc.Tags["tenant"] != "globex" || public.Tags["tenant"] != "initech" {
+		t.Errorf("Expected a copy's tags to be independent, got %v and %v", rc.Tags, public.Tags)
+	}
+
+	decoded, err := FromJSON(rc.JSON())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(decoded.Tags, expected) {
+		t.Errorf("Expected tags to round-trip, got %v", decoded.Tags)
+	}
+}
+
+func TestNewValidated(t *testing.T) {
+	create, err := NewValidated(1001, 404, codes.NotFound, "Not found", "extra")
+	if err != nil {
+		t.Fatalf("Expected valid arguments to succeed, got %v", err)
+	}
+	if rc := create(); rc.Code != 1001 || rc.Data != "extra" {
+		t.Errorf("Expected creator to behave like New, got %v", rc)
+	}
+
+	tests := []struct {
+		name     string
+		code     uint64
+		hCode    int
+		rCode    codes.Code
+		message  string
+		expected string
+	}{
+		{"zero code", 0, 404, codes.NotFound, "Not found", "code cannot be 0"},
+		{"zero http", 1001, 0, codes.NotFound, "Not found", "http code 0 must be between 100 and 599"},
+		{"http too large", 1001, 600, codes.NotFound, "Not found", "http code 600"},
+		{"unknown grpc", 1001, 404, codes.Code(99), "Not found", "grpc code 99 must be between 0 and 16"},
+		{"empty message", 1001, 404, codes.NotFound, "", "message cannot be empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			create, err := NewValidated(tt.code, tt.hCode, tt.rCode, tt.message)
+			if err == nil || !contains(err.Error(), tt.expected) {
+				t.Errorf("Expected error containing %q, got %v", tt.expected, err)
+			}
+			if create != nil {
+				t.Error("Expected nil creator on error")
+			}
+		})
+	}
+}
+
+func TestMustNew(t *testing.T) {
+	if rc := MustNew(1001, 404, codes.NotFound, "Not found")(); rc.Code != 1001 {
+		t.Errorf("Expected code 1001, got %d", rc.Code)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected MustNew to panic on invalid arguments")
+		}
+	}()
+	MustNew(0, 0, 99, "")
+}
+
+func TestRC_Summary(t *testing.T) {
+	rc := New(20001, 404, codes.NotFound, "Policy not found")()
+	if got := rc.Summary(); got != "[20001 NotFound] Policy not found" {
+		t.Errorf("Expected '[20001 NotFound] Policy not found', got %q", got)
+	}
+
+	multiline := New(1, 500, codes.Internal, "line one\nline two")()
+	if got := multiline.Summary(); got != "[1 Internal] line one line two" {
+		t.Errorf("Expected newlines collapsed, got %q", got)
+	}
+}
+
+func TestRC_Summary_Truncated(t *testing.T) {
+	rc := New(20001, 404, codes.NotFound, strings.Repeat("x", 500))()
+	got := rc.Summary()
+	if len([]rune(got)) != 120 {
+		t.Errorf("Expected summary of 120 runes, got %d", len([]rune(got)))
+	}
+	if !strings.HasPrefix(got, "[20001 NotFound] x") || !strings.HasSuffix(got, "...") {
+		t.Errorf("Expected truncated summary, got %q", got)
+	}
+}
+
 // Helper function to check if string contains substring
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(s) > len(substr) && (s[:len(substr)] == substr || s[len(s)-len(substr):] == substr || indexOf(s, substr) >= 0))
@@ -279,3 +816,31 @@ func BenchmarkRC_JSON(b *testing.B) {
 		_ = rc.JSON()
 	}
 }
+
+func BenchmarkRC_JSON_Minimal(b *testing.B) {
+	rc := New(1000, 400, codes.InvalidArgument, "benchmark error")()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = rc.JSON()
+	}
+}
+
+func BenchmarkRC_JSON_AllFields(b *testing.B) {
+	rc := New(1000, 400, codes.InvalidArgument, "benchmark error", "data")(errors.New("wrapped error"))
+	rc.WithService("billing").WithSuggestion("retry").WithUUID("fbc488b4-234a-5ebc-8817-2228da1eb817").WithTag("tenant", "acme")
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = rc.JSON()
+	}
+}
+
+func BenchmarkRC_JSON_Filtered(b *testing.B) {
+	rc := New(1000, 400, codes.InvalidArgument, "benchmark error")()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = rc.JSON("code", "message")
+	}
+}
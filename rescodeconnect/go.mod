@@ -0,0 +1,24 @@
+module github.com/restayway/rescode/rescodeconnect
+
+go 1.20
+
+require (
+	connectrpc.com/connect v1.16.2
+	github.com/restayway/rescode v1.1.0
+	google.golang.org/grpc v1.56.3
+	google.golang.org/protobuf v1.34.2
+)
+
+require (
+	github.com/golang/protobuf v1.5.3 // indirect
+	golang.org/x/net v0.23.0 // indirect
+	golang.org/x/sys v0.18.0 // indirect
+	golang.org/x/text v0.14.0 // indirect
+	google.golang.org/genproto v0.0.0-20230410155749-daa745c078e1 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+// Builds inside this repository use the local rescode tree. Replace
+// directives are ignored by consumers, who get the required release above,
+// so tag rescode before tagging this module.
+replace github.com/restayway/rescode => ../
@@ -0,0 +1,53 @@
+// Package rescodeconnect converts rescode errors to Connect (connectrpc.com)
+// errors. It lives in its own module so the core rescode module does not
+// depend on Connect.
+package rescodeconnect
+
+import (
+	"errors"
+
+	"connectrpc.com/connect"
+	"github.com/restayway/rescode"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+)
+
+// ToConnectError converts err to a *connect.Error. If err is, or wraps, an
+// RC, the result carries its gRPC code and public Message, and the code and
+// Data are attached as a google.protobuf.Struct error detail, as with
+// GRPCStatus. The wrapped cause is not exposed. Connect has no OK code, so an
+// RC with codes.OK maps to connect.CodeUnknown like any other failure. A
+// *connect.Error is returned unchanged, any other error maps to
+// connect.CodeUnknown, and nil returns nil.
+func ToConnectError(err error) *connect.Error {
+	if err == nil {
+		return nil
+	}
+
+	var connectErr *connect.Error
+	if errors.As(err, &connectErr) {
+		return connectErr
+	}
+
+	var rc *rescode.RC
+	if !errors.As(err, &rc) {
+		return connect.NewError(connect.CodeUnknown, err)
+	}
+	if rc.RpcCode == codes.OK {
+		return connect.NewError(connect.CodeUnknown, errors.New(rc.Message))
+	}
+
+	// Connect codes share their numeric values with gRPC codes
+	st := rc.GRPCStatus()
+	result := connect.NewError(connect.Code(rc.RpcCode), errors.New(rc.Message))
+	for _, detail := range st.Details() {
+		msg, ok := detail.(proto.Message)
+		if !ok {
+			continue
+		}
+		if d, err := connect.NewErrorDetail(msg); err == nil {
+			result.AddDetail(d)
+		}
+	}
+	return result
+}
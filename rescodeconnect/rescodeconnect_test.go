@@ -0,0 +1,81 @@
+package rescodeconnect
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/restayway/rescode"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestToConnectError(t *testing.T) {
+	rc := rescode.New(20001, 404, codes.NotFound, "Policy not found")()
+	rc.SetData(map[string]any{"id": "p1"})
+
+	ce := ToConnectError(fmt.Errorf("lookup: %w", rc))
+	if ce.Code() != connect.CodeNotFound {
+		t.Errorf("Expected code %v, got %v", connect.CodeNotFound, ce.Code())
+	}
+	if ce.Message() != "Policy not found" {
+		t.Errorf("Expected message 'Policy not found', got %q", ce.Message())
+	}
+
+	details := ce.Details()
+	if len(details) != 1 {
+		t.Fatalf("Expected 1 detail, got %d", len(details))
+	}
+	value, err := details[0].Value()
+	if err != nil {
+		t.Fatalf("Failed to decode detail: %v", err)
+	}
+	fields := value.(*structpb.Struct).AsMap()
+	if fields["code"] != "20001" {
+		t.Errorf("Expected code detail '20001', got %v", fields["code"])
+	}
+	if data, _ := fields["data"].(map[string]any); data["id"] != "p1" {
+		t.Errorf("Expected data detail with id p1, got %v", fields["data"])
+	}
+}
+
+func TestToConnectError_NonRC(t *testing.T) {
+	if ToConnectError(nil) != nil {
+		t.Error("Expected nil for a nil error")
+	}
+
+	ce := ToConnectError(errors.New("boom"))
+	if ce.Code() != connect.CodeUnknown {
+		t.Errorf("Expected code %v, got %v", connect.CodeUnknown, ce.Code())
+	}
+
+	existing := connect.NewError(connect.CodeAborted, errors.New("aborted"))
+	if ToConnectError(existing) != existing {
+		t.Error("Expected an existing connect error to be returned unchanged")
+	}
+}
+
+func TestToConnectError_HidesCause(t *testing.T) {
+	rc := rescode.New(20002, 500, codes.Internal, "Storage failed")(errors.New("dial tcp 10.0.0.1:5432: refused"))
+
+	ce := ToConnectError(rc)
+	if ce.Message() != "Storage failed" {
+		t.Errorf("Expected message 'Storage failed', got %q", ce.Message())
+	}
+}
+
+func TestToConnectError_OK(t *testing.T) {
+	rc := rescode.New(20003, 200, codes.OK, "Accepted")()
+
+	ce := ToConnectError(rc)
+	if ce == nil {
+		t.Fatal("Expected an error for an OK-coded RC, got nil")
+	}
+	if ce.Code() != connect.CodeUnknown {
+		t.Errorf("Expected CodeUnknown, got %v", ce.Code())
+	}
+	if ce.Message() != "Accepted" {
+		t.Errorf("Expected message 'Accepted', got %q", ce.Message())
+	}
+}
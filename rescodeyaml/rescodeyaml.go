@@ -0,0 +1,19 @@
+// Package rescodeyaml renders rescode errors as YAML for debugging tools. It
+// is kept apart from the core package so that depending on rescode does not
+// pull in a YAML library.
+package rescodeyaml
+
+import (
+	"github.com/restayway/rescode"
+	"gopkg.in/yaml.v3"
+)
+
+// Marshal returns the map from rc.JSON rendered as YAML with keys in sorted
+// order.
+func Marshal(rc *rescode.RC) (string, error) {
+	out, err := yaml.Marshal(rc.JSON())
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
@@ -0,0 +1,30 @@
+package rescodeyaml
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/restayway/rescode"
+	"google.golang.org/grpc/codes"
+)
+
+func TestMarshal(t *testing.T) {
+	rc := rescode.New(1401, 404, codes.NotFound, "not found", map[string]string{"id": "42"})(errors.New("no rows"))
+
+	out, err := Marshal(rc)
+	if err != nil {
+		t.Fatalf("Failed to render YAML: %v", err)
+	}
+
+	expected := `code: 1401
+data:
+    id: "42"
+httpCode: 404
+message: not found
+originalError: no rows
+rpcCode: 5
+`
+	if out != expected {
+		t.Errorf("Unexpected YAML:\n%s\nexpected:\n%s", out, expected)
+	}
+}
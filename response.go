@@ -0,0 +1,16 @@
+package rescode
+
+// Response is a statically-typed JSON body for returning an error from an
+// API handler. Unlike JSON, it never includes the wrapped error.
+type Response struct {
+	Code       uint64 `json:"code"`
+	Message    string `json:"message"`
+	Data       any    `json:"data,omitempty"`
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+// Response returns the client-facing body for the error.
+func (r *RC) Response() Response {
+	data, _ := r.guarded()
+	return Response{Code: r.Code, Message: r.Message, Data: data, Suggestion: r.Suggestion}
+}
@@ -0,0 +1,28 @@
+package rescode
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+)
+
+func TestRC_Response(t *testing.T) {
+	rc := New(1601, 404, codes.NotFound, "Policy not found")(errors.New("no rows"))
+
+	data, err := json.Marshal(rc.Response())
+	if err != nil {
+		t.Fatalf("Failed to marshal response: %v", err)
+	}
+
+	expected := `{"code":1601,"message":"Policy not found"}`
+	if string(data) != expected {
+		t.Errorf("Expected %s, got %s", expected, data)
+	}
+
+	rc.SetData(map[string]string{"id": "p1"})
+	if resp := rc.Response(); resp.Data == nil {
+		t.Error("Expected Response to carry Data")
+	}
+}
@@ -0,0 +1,38 @@
+package rescode
+
+import (
+	"hash/fnv"
+	"strconv"
+	"sync"
+)
+
+// SampleKey returns a stable key identifying the kind of error, made of the
+// code and a fingerprint of the message, for use by log samplers.
+func (r *RC) SampleKey() string {
+	h := fnv.New32a()
+	h.Write([]byte(r.Message))
+	return strconv.FormatUint(r.Code, 10) + ":" + strconv.FormatUint(uint64(h.Sum32()), 16)
+}
+
+// NewSampler returns a function that admits one in every rate errors sharing
+// the same SampleKey, starting with the first. A rate of 1 or less admits
+// every error. The returned function is safe for concurrent use.
+func NewSampler(rate int) func(*RC) bool {
+	var mu sync.Mutex
+	counts := make(map[string]int)
+
+	return func(r *RC) bool {
+		if rate <= 1 {
+			return true
+		}
+
+		key := r.SampleKey()
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		n := counts[key]
+		counts[key] = (n + 1) % rate
+		return n == 0
+	}
+}
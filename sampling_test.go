@@ -0,0 +1,51 @@
+package rescode
+
+import (
+	"testing"
+
+	"google.golang.org/grpc/codes"
+)
+
+func TestRC_SampleKey(t *testing.T) {
+	a := New(1501, 500, codes.Internal, "internal error")()
+	b := New(1501, 500, codes.Internal, "internal error")(nil)
+	c := New(1501, 500, codes.Internal, "another message")()
+
+	if a.SampleKey() != b.SampleKey() {
+		t.Errorf("Expected equal sample keys, got %q and %q", a.SampleKey(), b.SampleKey())
+	}
+	if a.SampleKey() == c.SampleKey() {
+		t.Errorf("Expected different sample keys for different messages, got %q", a.SampleKey())
+	}
+}
+
+func TestNewSampler(t *testing.T) {
+	sample := NewSampler(10)
+	frequent := New(1502, 503, codes.Unavailable, "service unavailable")
+	rare := New(1503, 400, codes.InvalidArgument, "invalid")
+
+	admitted := 0
+	for i := 0; i < 1000; i++ {
+		if sample(frequent()) {
+			admitted++
+		}
+	}
+	if admitted != 100 {
+		t.Errorf("Expected 100 of 1000 repeated errors to be admitted, got %d", admitted)
+	}
+
+	if !sample(rare()) {
+		t.Error("Expected the first occurrence of a new key to be admitted")
+	}
+}
+
+func TestNewSampler_AdmitAll(t *testing.T) {
+	sample := NewSampler(1)
+	rc := New(1504, 500, codes.Internal, "internal error")()
+
+	for i := 0; i < 5; i++ {
+		if !sample(rc) {
+			t.Fatal("Expected a rate of 1 to admit every error")
+		}
+	}
+}
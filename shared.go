@@ -0,0 +1,41 @@
+package rescode
+
+import "google.golang.org/grpc/codes"
+
+// NewShared is like New but calls without an error all return the same RC,
+// allocated once, so hot paths that never wrap a cause or set data create
+// errors without allocating. Calls with an error allocate a fresh RC as New
+// does.
+//
+// The shared RC is read-only: SetData, AppendData, WrapWith, WithService,
+// WithSuggestion, WithUUID, WithTag and Release panic on it, and its fields
+// must not be assigned. Public returns an ordinary, mutable copy. No caller is recorded
+// for it, even with EnableCaller.
+func NewShared(code uint64, hCode int, rCode codes.Code, message string, data ...any) RcCreator {
+	create := New(code, hCode, rCode, message, data...)
+
+	shared := &RC{
+		Code:     code,
+		Message:  message,
+		HttpCode: hCode,
+		RpcCode:  rCode,
+		shared:   true,
+	}
+	if len(data) > 0 {
+		shared.Data = data[0]
+	}
+
+	return func(errs ...error) *RC {
+		if len(errs) == 0 {
+			return shared
+		}
+		return create(errs...)
+	}
+}
+
+// mustBeMutable panics if r is a shared RC created by NewShared.
+func (r *RC) mustBeMutable(method string) {
+	if r.shared {
+		panic("rescode: " + method + " called on a read-only RC from NewShared")
+	}
+}
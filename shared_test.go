@@ -0,0 +1,86 @@
+package rescode
+
+import (
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+)
+
+func TestNewShared_ReusesRCWithoutError(t *testing.T) {
+	create := NewShared(20001, 404, codes.NotFound, "Policy not found")
+
+	first, second := create(), create()
+	if first != second {
+		t.Error("Expected calls without an error to return the same RC")
+	}
+	if !first.Equal(New(20001, 404, codes.NotFound, "Policy not found")()) {
+		t.Errorf("Expected shared RC to equal its New counterpart, got %v", first)
+	}
+}
+
+func TestNewShared_AllocatesWithError(t *testing.T) {
+	create := NewShared(20001, 404, codes.NotFound, "Policy not found")
+	cause := errors.New("cause")
+
+	rc := create(cause)
+	if rc == create() {
+		t.Error("Expected a call with an error to return a fresh RC")
+	}
+	if rc.OriginalError() != cause || rc.Error() != "Policy not found: cause" {
+		t.Errorf("Expected RC wrapping cause, got %v", rc)
+	}
+	if create().OriginalError() != nil {
+		t.Error("Expected shared RC to stay without a cause")
+	}
+
+	rc.SetData("mutable")
+	if rc.Data != "mutable" {
+		t.Errorf("Expected data to be set on a fresh RC, got %v", rc.Data)
+	}
+}
+
+func TestNewShared_MutationPanics(t *testing.T) {
+	create := NewShared(20001, 404, codes.NotFound, "Policy not found")
+
+	mutations := map[string]func(*RC){
+		"SetData":        func(rc *RC) { rc.SetData("x") },
+		"AppendData":     func(rc *RC) { rc.AppendData("k", "v") },
+		"WrapWith":       func(rc *RC) { rc.WrapWith(errors.New("cause")) },
+		"WithService":    func(rc *RC) { rc.WithService("svc") },
+		"WithSuggestion": func(rc *RC) { rc.WithSuggestion("retry") },
+		"WithUUID":       func(rc *RC) { rc.WithUUID("fbc488b4-234a-5ebc-8817-2228da1eb817") },
+		"WithTag":        func(rc *RC) { rc.WithTag("tenant", "acme") },
+		"Release":        func(rc *RC) { rc.Release() },
+	}
+	for name, mutate := range mutations {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Expected %s to panic on a shared RC", name)
+				}
+			}()
+			mutate(create())
+		})
+	}
+
+	if create().Data != nil || create().Service != "" {
+		t.Errorf("Expected shared RC to be unchanged, got %v", create())
+	}
+
+	public := create().Public()
+	public.SetData("x")
+	if public.Data != "x" {
+		t.Errorf("Expected Public copy to be mutable, got %v", public.Data)
+	}
+}
+
+func TestNewShared_ZeroAllocs(t *testing.T) {
+	create := NewShared(20001, 404, codes.NotFound, "Policy not found")
+	allocs := testing.AllocsPerRun(100, func() {
+		benchmarkSink = create()
+	})
+	if allocs != 0 {
+		t.Errorf("Expected 0 allocations per call, got %v", allocs)
+	}
+}
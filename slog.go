@@ -0,0 +1,41 @@
+//go:build go1.21
+
+package rescode
+
+import "log/slog"
+
+// LogValue implements slog.LogValuer, so logging an RC emits its fields as a
+// group of structured attributes rather than its Error() string. Attribute
+// names follow SetJSONKeys; data, originalError, service, suggestion, uuid
+// and caller are included only when present.
+func (r *RC) LogValue() slog.Value {
+	names := currentJSONKeys()
+	attrs := []slog.Attr{
+		slog.Uint64(names.Code, r.Code),
+		slog.String(names.Message, r.Message),
+		slog.Int(names.HTTPCode, r.HttpCode),
+		slog.Int(names.RPCCode, int(r.RpcCode)),
+	}
+
+	data, err := r.guarded()
+	if data != nil {
+		attrs = append(attrs, slog.Any(names.Data, data))
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String(names.OriginalError, formatOriginalError(err)))
+	}
+	if service := r.serviceName(); service != "" {
+		attrs = append(attrs, slog.String(names.Service, service))
+	}
+	if r.Suggestion != "" {
+		attrs = append(attrs, slog.String(names.Suggestion, r.Suggestion))
+	}
+	if r.UUID != "" {
+		attrs = append(attrs, slog.String(names.UUID, r.UUID))
+	}
+	if r.caller != "" {
+		attrs = append(attrs, slog.String(names.Caller, r.caller))
+	}
+
+	return slog.GroupValue(attrs...)
+}
@@ -0,0 +1,74 @@
+//go:build go1.21
+
+package rescode
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+)
+
+func TestRC_LogValue(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	rc := New(1601, 404, codes.NotFound, "Policy not found")(errors.New("no rows"))
+	rc.SetData(map[string]string{"id": "p1"})
+	logger.Error("failed", "err", rc)
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Failed to parse log output: %v", err)
+	}
+
+	group, ok := entry["err"].(map[string]any)
+	if !ok {
+		t.Fatalf("Expected err to be logged as a group, got %v", entry["err"])
+	}
+
+	expected := map[string]any{
+		"code":          float64(1601),
+		"message":       "Policy not found",
+		"httpCode":      float64(404),
+		"rpcCode":       float64(codes.NotFound),
+		"originalError": "no rows",
+	}
+	for key, want := range expected {
+		if group[key] != want {
+			t.Errorf("Expected %s to be %v, got %v", key, want, group[key])
+		}
+	}
+	if data, ok := group["data"].(map[string]any); !ok || data["id"] != "p1" {
+		t.Errorf("Expected data attribute, got %v", group["data"])
+	}
+}
+
+func TestRC_LogValue_OmitsEmpty(t *testing.T) {
+	rc := New(1001, 500, codes.Internal, "Internal error")()
+
+	attrs := rc.LogValue().Group()
+	if len(attrs) != 4 {
+		t.Errorf("Expected 4 attributes without data or cause, got %d", len(attrs))
+	}
+}
+
+func TestRC_LogValue_OriginalErrorFormatter(t *testing.T) {
+	t.Cleanup(func() { SetOriginalErrorFormatter(nil) })
+	SetOriginalErrorFormatter(func(error) string { return "[redacted]" })
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	logger.Error("failed", "err", New(1603, 500, codes.Internal, "Storage failed")(errors.New("password=hunter2")))
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Failed to parse log output: %v", err)
+	}
+	if group, _ := entry["err"].(map[string]any); group["originalError"] != "[redacted]" {
+		t.Errorf("Expected the formatted originalError, got %v", entry["err"])
+	}
+}
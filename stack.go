@@ -0,0 +1,52 @@
+package rescode
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// maxStackDepth bounds the number of frames captured for an RC.
+const maxStackDepth = 64
+
+// RecoverWithStack builds an RC from a value returned by recover. The RC is
+// created by internal, wraps the recovered value (as-is if it is an error,
+// otherwise formatted as "panic: <value>") and records the stack at the
+// recovery point, which includes the frames of the panicking function:
+//
+//	defer func() {
+//		if p := recover(); p != nil {
+//			err = rescode.RecoverWithStack(p, errs.Internal)
+//		}
+//	}()
+func RecoverWithStack(recovered any, internal RcCreator) *RC {
+	cause, ok := recovered.(error)
+	if !ok {
+		cause = fmt.Errorf("panic: %v", recovered)
+	}
+
+	rc := internal(cause)
+	pcs := make([]uintptr, maxStackDepth)
+	n := runtime.Callers(2, pcs)
+	rc.stack = pcs[:n]
+	return rc
+}
+
+// Stack returns the captured stack trace, one "function\n\tfile:line" entry
+// per frame, or "" if none was captured.
+func (r *RC) Stack() string {
+	if len(r.stack) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	frames := runtime.CallersFrames(r.stack)
+	for {
+		frame, more := frames.Next()
+		fmt.Fprintf(&b, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		if !more {
+			break
+		}
+	}
+	return b.String()
+}
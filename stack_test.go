@@ -0,0 +1,57 @@
+package rescode
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+)
+
+var errInternal = New(1500, 500, codes.Internal, "Internal error")
+
+func panicsWith(value any) {
+	panic(value)
+}
+
+func recoverFrom(value any) (rc *RC) {
+	defer func() {
+		rc = RecoverWithStack(recover(), errInternal)
+	}()
+	panicsWith(value)
+	return nil
+}
+
+func TestRecoverWithStack(t *testing.T) {
+	rc := recoverFrom("boom")
+
+	if rc.Code != 1500 {
+		t.Errorf("Expected code 1500, got %d", rc.Code)
+	}
+	if rc.OriginalError() == nil || rc.OriginalError().Error() != "panic: boom" {
+		t.Errorf("Expected wrapped panic value, got %v", rc.OriginalError())
+	}
+
+	stack := rc.Stack()
+	if !strings.Contains(stack, "rescode.panicsWith") {
+		t.Errorf("Expected stack to include the panicking function, got:\n%s", stack)
+	}
+	if !strings.Contains(stack, "stack_test.go:") {
+		t.Errorf("Expected stack to include file and line, got:\n%s", stack)
+	}
+}
+
+func TestRecoverWithStack_Error(t *testing.T) {
+	cause := errors.New("nil map write")
+	rc := recoverFrom(cause)
+
+	if rc.OriginalError() != cause {
+		t.Errorf("Expected recovered error to be wrapped as-is, got %v", rc.OriginalError())
+	}
+}
+
+func TestRC_Stack_Empty(t *testing.T) {
+	if stack := errInternal().Stack(); stack != "" {
+		t.Errorf("Expected no stack for a regular RC, got %q", stack)
+	}
+}
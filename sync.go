@@ -0,0 +1,53 @@
+package rescode
+
+import (
+	"sync"
+
+	"google.golang.org/grpc/codes"
+)
+
+// NewSync is like New but the RCs it creates guard Data and the wrapped error
+// with a mutex, so SetData, AppendData and WrapWith may run concurrently with
+// any method that reads them, such as JSON, Error, GRPCStatus and Response.
+// Direct access to the Data field is not guarded.
+//
+// Every guarded call takes the lock, and each RC allocates its own mutex, so
+// prefer New unless an RC is shared and mutated across goroutines.
+func NewSync(code uint64, hCode int, rCode codes.Code, message string, data ...any) RcCreator {
+	create := New(code, hCode, rCode, message, data...)
+	return func(errs ...error) *RC {
+		rc := create(errs...)
+		rc.mu = new(sync.RWMutex)
+		return rc
+	}
+}
+
+// noop is returned by lock and rlock for RCs without a mutex.
+func noop() {}
+
+// lock acquires the write lock of an RC created by NewSync and returns the
+// function releasing it.
+func (r *RC) lock() func() {
+	if r.mu == nil {
+		return noop
+	}
+	r.mu.Lock()
+	return r.mu.Unlock
+}
+
+// guarded returns Data and the wrapped error, read under the lock of an RC
+// created by NewSync.
+func (r *RC) guarded() (any, error) {
+	defer r.rlock()()
+	return r.Data, r.err
+}
+
+// rlock acquires the read lock of an RC created by NewSync and returns the
+// function releasing it.
+func (r *RC) rlock() func() {
+	if r.mu == nil {
+		return noop
+	}
+	r.mu.RLock()
+	return r.mu.RUnlock
+}
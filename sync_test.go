@@ -0,0 +1,78 @@
+package rescode
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+)
+
+func TestNewSync(t *testing.T) {
+	rc := NewSync(20001, 404, codes.NotFound, "Policy not found")(errors.New("cause"))
+	if rc.Code != 20001 || rc.Error() != "Policy not found: cause" {
+		t.Errorf("Expected PolicyNotFound wrapping cause, got %v", rc)
+	}
+	if !rc.Equal(New(20001, 404, codes.NotFound, "Policy not found")(errors.New("cause"))) {
+		t.Error("Expected a sync RC to equal its New counterpart")
+	}
+}
+
+// TestNewSync_Concurrent is meaningful under go test -race.
+func TestNewSync_Concurrent(t *testing.T) {
+	rc := NewSync(20001, 404, codes.NotFound, "Policy not found")()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(4)
+		go func(i int) {
+			defer wg.Done()
+			rc.SetData(map[string]int{"attempt": i})
+		}(i)
+		go func(i int) {
+			defer wg.Done()
+			rc.AppendData("last", i)
+		}(i)
+		go func() {
+			defer wg.Done()
+			_ = rc.JSON()
+			_ = rc.String()
+		}()
+		go func() {
+			defer wg.Done()
+			rc.WrapWith(errors.New("retry"))
+			_ = rc.Error()
+			_ = rc.Public()
+		}()
+	}
+	wg.Wait()
+
+	if rc.JSON()["data"] == nil {
+		t.Error("Expected data to be set")
+	}
+}
+
+// TestNewSync_ConcurrentReaders is meaningful under go test -race: every
+// method reading Data or the wrapped error must take the read lock.
+func TestNewSync_ConcurrentReaders(t *testing.T) {
+	rc := NewSync(20001, 404, codes.NotFound, "Policy not found")()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 1000; i++ {
+			rc.SetData(map[string]int{"attempt": i})
+			rc.WrapWith(errors.New("retry"))
+		}
+	}()
+
+	for i := 0; i < 1000; i++ {
+		_ = rc.Response()
+		_ = rc.GRPCStatus()
+		_ = rc.Equal(rc.Public())
+		_ = rc.OriginalErrorChain()
+		_ = rc.NormalizeCause(func(error) *RC { return nil })
+		_, _ = DataAs[map[string]int](rc)
+	}
+	<-done
+}